@@ -0,0 +1,179 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Case is case transformation requested by Modifier
+type Case uint8
+
+const (
+	CaseNone Case = iota
+	// CaseUpper is requested by flag '^'
+	CaseUpper
+	// CaseLower is requested by flag ','
+	CaseLower
+	// CaseTitle is requested by flag '~'
+	CaseTitle
+)
+
+// MaxModifierWidth limits width of Modifier
+const MaxModifierWidth = 64
+
+// Modifier changes how a token is written. It is written between '%' and
+// the token as flags, then pad and width, like "^" in "%^NM" or "04" in "%04R":
+//   - flags '^' upper case, ',' lower case, '~' title case, '#' Roman numerals
+//   - pad '0' zeroes or '_' spaces, given only together with width
+//   - width starts with digit from '1' to '9'
+type Modifier struct {
+	Case  Case
+	Roman bool
+	// Pad is '0' or ' ', 0 when not given
+	Pad byte
+	// Width is 0 when not given
+	Width int
+}
+
+// ParseModifier parses Modifier at start of s, returning how many bytes it
+// takes; s without modifier gives zero Modifier and n == 0
+func ParseModifier(s string) (m Modifier, n int, err error) {
+flags:
+	for ; n < len(s); n++ {
+		c := CaseNone
+		switch s[n] {
+		case '^':
+			c = CaseUpper
+		case ',':
+			c = CaseLower
+		case '~':
+			c = CaseTitle
+		case '#':
+			if m.Roman {
+				return Modifier{}, 0, fmt.Errorf("flag '#' repeated in modifier %q", s[:n+1])
+			}
+			m.Roman = true
+			continue
+		default:
+			break flags
+		}
+		if m.Case != CaseNone {
+			return Modifier{}, 0, fmt.Errorf("conflicting case flags in modifier %q", s[:n+1])
+		}
+		m.Case = c
+	}
+	if n+1 < len(s) && (s[n] == '0' || s[n] == '_') && s[n+1] >= '1' && s[n+1] <= '9' {
+		m.Pad = s[n]
+		if m.Pad == '_' {
+			m.Pad = ' '
+		}
+		n++
+	}
+	if n < len(s) && s[n] >= '1' && s[n] <= '9' {
+		for ; n < len(s) && s[n] >= '0' && s[n] <= '9'; n++ {
+			m.Width = m.Width*10 + int(s[n]-'0')
+			if m.Width > MaxModifierWidth {
+				return Modifier{}, 0, fmt.Errorf("width in modifier %q exceeds %d", s[:n+1], MaxModifierWidth)
+			}
+		}
+	}
+	return m, n, nil
+}
+
+// String returns m written the way ParseModifier reads it
+func (m Modifier) String() string {
+	var b []byte
+	switch m.Case {
+	case CaseUpper:
+		b = append(b, '^')
+	case CaseLower:
+		b = append(b, ',')
+	case CaseTitle:
+		b = append(b, '~')
+	}
+	if m.Roman {
+		b = append(b, '#')
+	}
+	if m.Width > 0 {
+		switch m.Pad {
+		case '0':
+			b = append(b, '0')
+		case ' ':
+			b = append(b, '_')
+		}
+		b = AppendPadded(b, m.Width, 0, ' ')
+	}
+	return string(b)
+}
+
+// ApplyCase returns s transformed to case c; CaseTitle makes
+// the first letter of each word upper case and the rest lower case
+func ApplyCase(s string, c Case) string {
+	switch c {
+	case CaseUpper:
+		return strings.ToUpper(s)
+	case CaseLower:
+		return strings.ToLower(s)
+	case CaseTitle:
+		var sb strings.Builder
+		sb.Grow(len(s))
+		inWord := false
+		for _, r := range s {
+			if inWord {
+				sb.WriteRune(unicode.ToLower(r))
+			} else {
+				sb.WriteRune(unicode.ToTitle(r))
+			}
+			inWord = unicode.IsLetter(r)
+		}
+		return sb.String()
+	}
+	return s
+}
+
+// AppendNumber appends n written as m requests; width and pad of token
+// are used unless m has width, and plus writes '+' before non-negative n.
+// Roman numerals are padded with spaces.
+func (m Modifier) AppendNumber(b []byte, n int, width int, pad byte, plus bool) []byte {
+	start := len(b)
+	if m.Width > 0 {
+		width, pad = m.Width, m.Pad
+		if pad == 0 {
+			pad = ' '
+		}
+	}
+	switch {
+	case m.Roman:
+		if plus && n >= 0 {
+			b = append(b, '+')
+		}
+		b = AppendRoman(b, n)
+	case plus:
+		b = AppendSigned(b, n, width, pad)
+	default:
+		b = AppendPadded(b, n, width, pad)
+	}
+	return m.Apply(b, start)
+}
+
+// Apply transforms case of b[start:] and left pads it with spaces to width
+// of m, counting columns of DisplayWidth
+func (m Modifier) Apply(b []byte, start int) []byte {
+	if m.Case != CaseNone {
+		s := ApplyCase(string(b[start:]), m.Case)
+		b = append(b[:start], s...)
+	}
+	padding := m.Width - displayWidth(b[start:])
+	if padding <= 0 {
+		return b
+	}
+	for range padding {
+		b = append(b, ' ')
+	}
+	copy(b[start+padding:], b[start:len(b)-padding])
+	for i := start; i < start+padding; i++ {
+		b[i] = ' '
+	}
+	return b
+}
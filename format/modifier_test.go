@@ -0,0 +1,131 @@
+package format_test
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+)
+
+func TestParseModifier(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  format.Modifier
+		expectedN int
+		expectErr bool
+	}{
+		{"R", format.Modifier{}, 0, false},
+		{"04R", format.Modifier{Pad: '0', Width: 4}, 2, false},
+		{"_6NM", format.Modifier{Pad: ' ', Width: 6}, 2, false},
+		{"12M", format.Modifier{Width: 12}, 2, false},
+		{"^NM", format.Modifier{Case: format.CaseUpper}, 1, false},
+		{",nS", format.Modifier{Case: format.CaseLower}, 1, false},
+		{"~#M", format.Modifier{Case: format.CaseTitle, Roman: true}, 2, false},
+		{"#5R", format.Modifier{Roman: true, Width: 5}, 2, false},
+		{"0M", format.Modifier{}, 0, false},
+		{"^,NM", format.Modifier{}, 0, true},
+		{"##M", format.Modifier{}, 0, true},
+		{"99R", format.Modifier{}, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			m, n, err := format.ParseModifier(test.input)
+			assert.Equal(t, err != nil, test.expectErr)
+			assert.Equal(t, m, test.expected)
+			assert.Equal(t, n, test.expectedN)
+			if err == nil {
+				assert.Equal(t, m.String(), test.input[:n])
+			}
+		})
+	}
+}
+
+func TestApplyCase(t *testing.T) {
+	assert.Equal(t, format.ApplyCase("Makara", format.CaseUpper), "MAKARA")
+	assert.Equal(t, format.ApplyCase("Makara", format.CaseLower), "makara")
+	assert.Equal(t, format.ApplyCase("mAKARA sOLIS", format.CaseTitle), "Makara Solis")
+	assert.Equal(t, format.ApplyCase("Makara", format.CaseNone), "Makara")
+}
+
+func TestModifierAppendNumber(t *testing.T) {
+	tests := []struct {
+		modifier format.Modifier
+		n        int
+		width    int
+		pad      byte
+		plus     bool
+		expected string
+	}{
+		{format.Modifier{}, 7, 2, '0', false, "07"},
+		{format.Modifier{Pad: '0', Width: 4}, 203, 0, ' ', false, "0203"},
+		{format.Modifier{Width: 4}, 203, 0, ' ', false, " 203"},
+		{format.Modifier{Pad: '0', Width: 5}, 221, 0, ' ', true, "+0221"},
+		{format.Modifier{Roman: true}, 4, 2, '0', false, "IV"},
+		{format.Modifier{Roman: true, Case: format.CaseLower, Width: 6}, 24, 0, ' ', false, "  xxiv"},
+		{format.Modifier{Roman: true}, -3, 0, ' ', true, "-III"},
+	}
+
+	for _, test := range tests {
+		result := string(test.modifier.AppendNumber([]byte("x"), test.n, test.width, test.pad, test.plus))
+		assert.Equal(t, result, "x"+test.expected)
+	}
+}
+
+func TestModifierApply(t *testing.T) {
+	m := format.Modifier{Case: format.CaseUpper, Width: 8}
+	assert.Equal(t, string(m.Apply([]byte("x=Makara"), 2)), "x=  MAKARA")
+	assert.Equal(t, string(m.Apply([]byte("Dhanus"), 0)), "  DHANUS")
+	assert.Equal(t, string(format.Modifier{Width: 2}.Apply([]byte("Makara"), 0)), "Makara")
+}
+
+func TestRoman(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{0, "N"},
+		{1, "I"},
+		{4, "IV"},
+		{9, "IX"},
+		{14, "XIV"},
+		{24, "XXIV"},
+		{203, "CCIII"},
+		{1994, "MCMXCIV"},
+		{-40, "-XL"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, format.Roman(test.n), test.expected)
+		n, nRunes, err := format.ParseRoman(test.expected + "=")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, n, test.n)
+		assert.Equal(t, nRunes, len(test.expected))
+	}
+}
+
+func TestParseRoman(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedN      int
+		expectedNRunes int
+		expectErr      bool
+	}{
+		{"xxiv", 24, 4, false},
+		{"Iv", 4, 2, false},
+		{"n", 0, 1, false},
+		{"IIII", 0, 0, true},
+		{"IC", 0, 0, true},
+		{"VX", 0, 0, true},
+		{"", 0, 0, true},
+		{"12", 0, 0, true},
+	}
+
+	for _, test := range tests {
+		n, nRunes, err := format.ParseRoman(test.input)
+		assert.Equal(t, err != nil, test.expectErr)
+		assert.Equal(t, n, test.expectedN)
+		assert.Equal(t, nRunes, test.expectedNRunes)
+	}
+}
@@ -0,0 +1,100 @@
+package format
+
+import "unicode/utf8"
+
+// NumeralSystem is a set of ten digits, from Zero to Zero+9,
+// that numbers are written with
+type NumeralSystem struct {
+	Name string
+	Zero rune
+}
+
+var (
+	ASCIIDigits         = NumeralSystem{"ASCII", '0'}
+	DevanagariDigits    = NumeralSystem{"Devanagari", '०'}
+	EasternArabicDigits = NumeralSystem{"Eastern Arabic", '٠'}
+	FullWidthDigits     = NumeralSystem{"Full-width", '０'}
+)
+
+// NumeralSystems are the systems ParseNumeric and ParseDecimal accept
+var NumeralSystems = []NumeralSystem{ASCIIDigits, DevanagariDigits, EasternArabicDigits, FullWidthDigits}
+
+// AppendPadded is AppendPadded writing digits of s; padding with '0'
+// writes zero of s and width counts characters
+func (s NumeralSystem) AppendPadded(b []byte, n int, width int, pad byte) []byte {
+	if s.Zero == '0' {
+		return AppendPadded(b, n, width, pad)
+	}
+	var buf [24]byte
+	for _, c := range AppendPadded(buf[:0], n, width, pad) {
+		if c >= '0' && c <= '9' {
+			b = utf8.AppendRune(b, s.Zero+rune(c-'0'))
+		} else {
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+// Iota is Iota writing digits of s
+func (s NumeralSystem) Iota(n int) string {
+	return string(s.AppendPadded(nil, n, 0, ' '))
+}
+
+// Pad is Pad2 and Pad9 writing digits of s with any width
+func (s NumeralSystem) Pad(n int, width int) string {
+	return string(s.AppendPadded(nil, n, width, '0'))
+}
+
+// Convert replaces digits of any of NumeralSystems in text with digits of s
+func (s NumeralSystem) Convert(text string) string {
+	b := make([]byte, 0, len(text))
+	for i := 0; i < len(text); {
+		if d, _, size, ok := digitAt(text[i:]); ok {
+			b = utf8.AppendRune(b, s.Zero+rune(d))
+			i += size
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		b = utf8.AppendRune(b, r)
+		i += size
+	}
+	return string(b)
+}
+
+// digitAt returns value of digit of any of NumeralSystems at start of s,
+// zero of its system and its length in bytes
+func digitAt(s string) (value int, zero rune, size int, ok bool) {
+	if len(s) == 0 {
+		return 0, 0, 0, false
+	}
+	if s[0] >= '0' && s[0] <= '9' {
+		return int(s[0] - '0'), '0', 1, true
+	}
+	if s[0] < utf8.RuneSelf {
+		return 0, 0, 0, false
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	for _, system := range NumeralSystems {
+		if r >= system.Zero && r <= system.Zero+9 {
+			return int(r - system.Zero), system.Zero, size, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// ScanDigits returns length in bytes of up to maxDigits digits at start
+// of s written in one of NumeralSystems, and number of the digits
+func ScanDigits(s string, maxDigits int) (length int, digits int) {
+	var zero rune
+	for digits < maxDigits {
+		_, z, size, ok := digitAt(s[length:])
+		if !ok || digits > 0 && z != zero {
+			break
+		}
+		zero = z
+		length += size
+		digits++
+	}
+	return
+}
@@ -0,0 +1,91 @@
+package format_test
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+)
+
+func TestNumeralSystems(t *testing.T) {
+	tests := []struct {
+		system   format.NumeralSystem
+		iota     string
+		padded   string
+		spaced   string
+		negative string
+	}{
+		{format.ASCIIDigits, "203", "0007", "   7", "-05"},
+		{format.DevanagariDigits, "२०३", "०००७", "   ७", "-०५"},
+		{format.EasternArabicDigits, "٢٠٣", "٠٠٠٧", "   ٧", "-٠٥"},
+		{format.FullWidthDigits, "２０３", "０００７", "   ７", "-０５"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.system.Name, func(t *testing.T) {
+			assert.Equal(t, test.system.Iota(203), test.iota)
+			assert.Equal(t, test.system.Pad(7, 4), test.padded)
+			assert.Equal(t, string(test.system.AppendPadded(nil, 7, 4, ' ')), test.spaced)
+			assert.Equal(t, string(test.system.AppendPadded(nil, -5, 3, '0')), test.negative)
+			assert.Equal(t, test.system.Convert("203"), test.iota)
+			assert.Equal(t, format.ASCIIDigits.Convert(test.system.Convert("221=08=26")), "221=08=26")
+		})
+	}
+}
+
+func TestParseNumericSystems(t *testing.T) {
+	tests := []struct {
+		input          string
+		maxDigits      int
+		expectedN      int
+		expectedNRunes int
+	}{
+		{"२०३=", 9, 203, 9},
+		{" ٢٠٣", 9, 203, 7},
+		{"０８２６", 2, 8, 6},
+		{"12३", 9, 12, 2},
+		{"२1", 9, 2, 3},
+	}
+
+	for _, test := range tests {
+		n, nRunes, err := format.ParseNumericMax(test.input, test.maxDigits)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, n, test.expectedN)
+		assert.Equal(t, nRunes, test.expectedNRunes)
+	}
+
+	n, nRunes, err := format.ParseSignedNumeric("-२२१", true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, n, -221)
+	assert.Equal(t, nRunes, 10)
+
+	value, consumed, err := format.ParseDecimal("३५|", 9)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, value, 350000000)
+	assert.Equal(t, consumed, 6)
+
+	_, _, err = format.ParseNumeric("x२")
+	assert.NotEqual(t, err, nil)
+}
+
+func TestScanDigits(t *testing.T) {
+	tests := []struct {
+		input          string
+		maxDigits      int
+		expectedLength int
+		expectedDigits int
+	}{
+		{"2210826T", 9, 7, 7},
+		{"२२१०८२६T", 9, 21, 7},
+		{"२२१०८२६T", 3, 9, 3},
+		{"１２3", 9, 6, 2},
+		{"T12", 9, 0, 0},
+	}
+
+	for _, test := range tests {
+		length, digits := format.ScanDigits(test.input, test.maxDigits)
+		assert.Equal(t, length, test.expectedLength)
+		assert.Equal(t, digits, test.expectedDigits)
+	}
+}
@@ -3,24 +3,69 @@ package format
 import (
 	"fmt"
 	"strconv"
-	"unicode"
+	"unicode/utf8"
 )
 
 func ParseNumeric(s string) (n int, nRunes int, err error) {
+	return ParseNumericMax(s, len(s))
+}
+
+// ParseNumericMax is ParseNumeric that consumes at most maxDigits digits,
+// so that numbers written next to each other, like "0826", can be split
+func ParseNumericMax(s string, maxDigits int) (n int, nRunes int, err error) {
 	// allow leading spaces
 	for nRunes < len(s) && s[nRunes] == ' ' {
 		nRunes++
 	}
 	start := nRunes
-	for nRunes < len(s) && s[nRunes] >= '0' && s[nRunes] <= '9' {
+	length, _ := ScanDigits(s[start:], maxDigits)
+	nRunes += length
+	if length == 0 {
+		err = fmt.Errorf("expected numeric value, got %q", s)
+		return
+	}
+	n, err = strconv.Atoi(asciiDigits(s[start:nRunes]))
+	return
+}
+
+// asciiDigits returns digits of any of NumeralSystems as ASCII digits
+func asciiDigits(digits string) string {
+	for i := 0; i < len(digits); i++ {
+		if digits[i] >= utf8.RuneSelf {
+			return ASCIIDigits.Convert(digits)
+		}
+	}
+	return digits
+}
+
+// ParseSignedNumeric is ParseNumeric that also accepts leading '+' or '-';
+// when requireSign is set, the sign must be present
+func ParseSignedNumeric(s string, requireSign bool) (n int, nRunes int, err error) {
+	for nRunes < len(s) && s[nRunes] == ' ' {
 		nRunes++
 	}
-	if start == nRunes {
-		err = fmt.Errorf("expected numeric value, got %q", s)
+	negative := false
+	if nRunes < len(s) && (s[nRunes] == '+' || s[nRunes] == '-') {
+		negative = s[nRunes] == '-'
+		nRunes++
+	} else if requireSign {
+		err = fmt.Errorf("expected '+' or '-' sign, got %q", s)
 		return
 	}
-	numStr := s[start:nRunes]
-	n, err = strconv.Atoi(numStr)
+	n, consumed, err := ParseNumeric(s[nRunes:])
+	if err != nil {
+		err = fmt.Errorf("expected numeric value, got %q", s)
+		return 0, 0, err
+	}
+	// spaces between sign and number are not allowed
+	if s[nRunes] == ' ' {
+		err = fmt.Errorf("expected numeric value, got %q", s)
+		return 0, 0, err
+	}
+	nRunes += consumed
+	if negative {
+		n = -n
+	}
 	return
 }
 
@@ -28,6 +73,11 @@ func Iota(n int) string {
 	return fmt.Sprintf("%d", n)
 }
 
+// Signed is Iota that always includes the sign, such as "+5" or "-5"
+func Signed(n int) string {
+	return fmt.Sprintf("%+d", n)
+}
+
 func Pad2(n int) string {
 	return fmt.Sprintf("%02d", n)
 }
@@ -40,21 +90,63 @@ func PadSpace(n int) string {
 	return fmt.Sprintf("%2d", n)
 }
 
+// Ordinal returns n followed by English ordinal suffix, such as "21st";
+// see OrdinalRule for other languages
 func Ordinal(n int) string {
-	if n%100 >= 11 && n%100 <= 13 {
-		return strconv.Itoa(n) + "th"
-	}
+	return EnglishOrdinal.Ordinal(n)
+}
+
+// AppendPadded appends decimal n to b, left padded with pad up to width bytes;
+// the sign of negative n precedes zero padding
+func AppendPadded(b []byte, n int, width int, pad byte) []byte {
+	return appendPadded(b, n, width, pad, false)
+}
 
-	switch n % 10 {
-	case 1:
-		return strconv.Itoa(n) + "st"
-	case 2:
-		return strconv.Itoa(n) + "nd"
-	case 3:
-		return strconv.Itoa(n) + "rd"
-	default:
-		return strconv.Itoa(n) + "th"
+// AppendSigned is AppendPadded that writes '+' before non-negative n
+func AppendSigned(b []byte, n int, width int, pad byte) []byte {
+	return appendPadded(b, n, width, pad, true)
+}
+
+func appendPadded(b []byte, n int, width int, pad byte, plus bool) []byte {
+	var digits [20]byte
+	i := len(digits)
+	u := uint64(n)
+	if n < 0 {
+		u = -u
+	}
+	for {
+		i--
+		digits[i] = byte('0' + u%10)
+		u /= 10
+		if u == 0 {
+			break
+		}
+	}
+	size := len(digits) - i
+	var sign byte
+	if n < 0 {
+		sign = '-'
+	} else if plus {
+		sign = '+'
+	}
+	if sign != 0 {
+		size++
 	}
+	if sign != 0 && pad == '0' {
+		b = append(b, sign)
+	}
+	for ; size < width; size++ {
+		b = append(b, pad)
+	}
+	if sign != 0 && pad != '0' {
+		b = append(b, sign)
+	}
+	return append(b, digits[i:]...)
+}
+
+// AppendOrdinal is Ordinal that appends to b
+func AppendOrdinal(b []byte, n int) []byte {
+	return EnglishOrdinal.AppendOrdinal(b, n)
 }
 
 func RemoveZeroesFromDecimalPortionOfNumber(s string) string {
@@ -67,18 +159,20 @@ func RemoveZeroesFromDecimalPortionOfNumber(s string) string {
 	return s
 }
 
+// ParseDecimal parses up to n digits at start of s as fraction scaled
+// by 10^n, so that "3" with n 9 gives 300000000
 func ParseDecimal(s string, n int) (value int, consumed int, err error) {
-	for consumed < len(s) && consumed < n && unicode.IsDigit(rune(s[consumed])) {
-		d := int(s[consumed] - '0')
-		value = value*10 + d
-		consumed++
-	}
-	if consumed == 0 {
+	length, digits := ScanDigits(s, n)
+	if digits == 0 {
 		return 0, 0, strconv.ErrSyntax
 	}
-	for consumed < n {
-		n -= 1
+	for i := 0; i < length; {
+		d, _, size, _ := digitAt(s[i:])
+		value = value*10 + d
+		i += size
+	}
+	for ; digits < n; digits++ {
 		value *= 10
 	}
-	return value, consumed, nil
+	return value, length, nil
 }
@@ -201,3 +201,143 @@ func TestParseDecimal(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSignedNumeric(t *testing.T) {
+	tests := []struct {
+		input          string
+		requireSign    bool
+		expectedN      int
+		expectedNRunes int
+		expectErr      bool
+	}{
+		{"123", false, 123, 3, false},
+		{"+123", false, 123, 4, false},
+		{"-123", false, -123, 4, false},
+		{" -123abc", false, -123, 5, false},
+		{"-0", false, 0, 2, false},
+		{"123", true, 0, 0, true},
+		{"+123", true, 123, 4, false},
+		{"-123", true, -123, 4, false},
+		{"- 123", false, 0, 0, true},
+		{"-", false, 0, 0, true},
+		{"", false, 0, 0, true},
+	}
+
+	for _, test := range tests {
+		n, nRunes, err := format.ParseSignedNumeric(test.input, test.requireSign)
+		if (err != nil) != test.expectErr {
+			t.Errorf("ParseSignedNumeric(%q, %v): unexpected err %v", test.input, test.requireSign, err)
+			continue
+		}
+		if n != test.expectedN {
+			t.Errorf("ParseSignedNumeric(%q, %v): n expected %d, got %d", test.input, test.requireSign, test.expectedN, n)
+		}
+		if nRunes != test.expectedNRunes {
+			t.Errorf("ParseSignedNumeric(%q, %v): nRunes expected %d, got %d", test.input, test.requireSign, test.expectedNRunes, nRunes)
+		}
+	}
+}
+
+func TestSigned(t *testing.T) {
+	tests := []struct {
+		input    int
+		expected string
+	}{
+		{0, "+0"},
+		{5, "+5"},
+		{-5, "-5"},
+		{221, "+221"},
+	}
+
+	for _, test := range tests {
+		result := format.Signed(test.input)
+		if result != test.expected {
+			t.Errorf("Signed(%d): expected %q, got %q", test.input, test.expected, result)
+		}
+	}
+}
+
+func TestAppendPadded(t *testing.T) {
+	tests := []struct {
+		input    int
+		width    int
+		pad      byte
+		expected string
+	}{
+		{0, 0, ' ', "0"},
+		{7, 2, '0', "07"},
+		{7, 2, ' ', " 7"},
+		{123, 2, '0', "123"},
+		{5, 9, '0', "000000005"},
+		{-5, 3, '0', "-05"},
+		{-5, 3, ' ', " -5"},
+		{-9223372036854775808, 0, ' ', "-9223372036854775808"},
+	}
+
+	for _, test := range tests {
+		result := string(format.AppendPadded([]byte("x"), test.input, test.width, test.pad))
+		if result != "x"+test.expected {
+			t.Errorf("AppendPadded(%d, %d, %q): expected %q, got %q", test.input, test.width, test.pad, "x"+test.expected, result)
+		}
+	}
+}
+
+func TestAppendSigned(t *testing.T) {
+	tests := []struct {
+		input    int
+		width    int
+		pad      byte
+		expected string
+	}{
+		{0, 0, ' ', "+0"},
+		{221, 5, '0', "+0221"},
+		{221, 5, ' ', " +221"},
+		{-3, 4, '0', "-003"},
+	}
+
+	for _, test := range tests {
+		result := string(format.AppendSigned(nil, test.input, test.width, test.pad))
+		if result != test.expected {
+			t.Errorf("AppendSigned(%d, %d, %q): expected %q, got %q", test.input, test.width, test.pad, test.expected, result)
+		}
+	}
+}
+
+func TestAppendOrdinal(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 4, 11, 12, 13, 21, 22, 23, 101, 111, 112, 113} {
+		result := string(format.AppendOrdinal(nil, n))
+		if result != format.Ordinal(n) {
+			t.Errorf("AppendOrdinal(%d): expected %q, got %q", n, format.Ordinal(n), result)
+		}
+	}
+}
+
+func TestParseNumericMax(t *testing.T) {
+	tests := []struct {
+		input          string
+		maxDigits      int
+		expectedN      int
+		expectedNRunes int
+		expectErr      bool
+	}{
+		{"0826", 2, 8, 2, false},
+		{"26T14", 2, 26, 2, false},
+		{"8=", 2, 8, 1, false},
+		{" 8", 2, 8, 2, false},
+		{"123456789012", 9, 123456789, 9, false},
+		{"123", 5, 123, 3, false},
+		{"abc", 2, 0, 0, true},
+		{"12", 0, 0, 0, true},
+	}
+
+	for _, test := range tests {
+		n, nRunes, err := format.ParseNumericMax(test.input, test.maxDigits)
+		if (err != nil) != test.expectErr {
+			t.Errorf("ParseNumericMax(%q, %d): unexpected err %v", test.input, test.maxDigits, err)
+			continue
+		}
+		if n != test.expectedN || nRunes != test.expectedNRunes {
+			t.Errorf("ParseNumericMax(%q, %d): expected (%d, %d), got (%d, %d)", test.input, test.maxDigits, test.expectedN, test.expectedNRunes, n, nRunes)
+		}
+	}
+}
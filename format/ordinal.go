@@ -0,0 +1,63 @@
+package format
+
+import "fmt"
+
+// OrdinalRule gives suffix written after number n to make it ordinal,
+// such as "st" for 1 in English
+type OrdinalRule func(n int) string
+
+var (
+	// EnglishOrdinal writes 1st, 2nd, 3rd, 4th, 11th, 21st
+	EnglishOrdinal OrdinalRule = englishOrdinal
+	// FrenchOrdinal writes 1er, 2e, 3e
+	FrenchOrdinal OrdinalRule = func(n int) string {
+		if n == 1 {
+			return "er"
+		}
+		return "e"
+	}
+	// GermanOrdinal writes 1., 2., 3.
+	GermanOrdinal OrdinalRule = func(n int) string { return "." }
+	// SpanishOrdinal writes 1º, 2º for masculine nouns
+	SpanishOrdinal OrdinalRule = func(n int) string { return "º" }
+	// SpanishFeminineOrdinal writes 1ª, 2ª for feminine nouns
+	SpanishFeminineOrdinal OrdinalRule = func(n int) string { return "ª" }
+)
+
+func englishOrdinal(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// Ordinal returns n followed by its suffix, such as "1er" for FrenchOrdinal
+func (r OrdinalRule) Ordinal(n int) string {
+	return string(r.AppendOrdinal(nil, n))
+}
+
+// AppendOrdinal is Ordinal that appends to b
+func (r OrdinalRule) AppendOrdinal(b []byte, n int) []byte {
+	return append(AppendPadded(b, n, 0, ' '), r(n)...)
+}
+
+// ParseOrdinal parses number at start of s followed by its suffix of r
+func (r OrdinalRule) ParseOrdinal(s string) (n int, nRunes int, err error) {
+	n, nRunes, err = ParseNumeric(s)
+	if err != nil {
+		return 0, 0, err
+	}
+	suffix := r(n)
+	if len(s)-nRunes < len(suffix) || s[nRunes:nRunes+len(suffix)] != suffix {
+		return 0, 0, fmt.Errorf("expected ordinal suffix %q, got %q", suffix, s[nRunes:])
+	}
+	return n, nRunes + len(suffix), nil
+}
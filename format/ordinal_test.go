@@ -0,0 +1,43 @@
+package format_test
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+)
+
+func TestOrdinalRules(t *testing.T) {
+	tests := []struct {
+		rule     format.OrdinalRule
+		n        int
+		expected string
+	}{
+		{format.EnglishOrdinal, 1, "1st"},
+		{format.EnglishOrdinal, 12, "12th"},
+		{format.EnglishOrdinal, 23, "23rd"},
+		{format.FrenchOrdinal, 1, "1er"},
+		{format.FrenchOrdinal, 2, "2e"},
+		{format.FrenchOrdinal, 21, "21e"},
+		{format.GermanOrdinal, 1, "1."},
+		{format.GermanOrdinal, 26, "26."},
+		{format.SpanishOrdinal, 1, "1º"},
+		{format.SpanishFeminineOrdinal, 3, "3ª"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.rule.Ordinal(test.n), test.expected)
+		assert.Equal(t, string(test.rule.AppendOrdinal([]byte("x"), test.n)), "x"+test.expected)
+
+		n, nRunes, err := test.rule.ParseOrdinal(test.expected + " sol")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, n, test.n)
+		assert.Equal(t, nRunes, len(test.expected))
+	}
+
+	_, _, err := format.FrenchOrdinal.ParseOrdinal("1e")
+	assert.NotEqual(t, err, nil)
+	_, _, err = format.SpanishOrdinal.ParseOrdinal("1ª")
+	assert.NotEqual(t, err, nil)
+}
@@ -0,0 +1,97 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"},
+	{900, "CM"},
+	{500, "D"},
+	{400, "CD"},
+	{100, "C"},
+	{90, "XC"},
+	{50, "L"},
+	{40, "XL"},
+	{10, "X"},
+	{9, "IX"},
+	{5, "V"},
+	{4, "IV"},
+	{1, "I"},
+}
+
+// Roman returns n in Roman numerals, such as "XXIV" for 24; zero is "N"
+// (nulla) and negative numbers are prefixed with '-'
+func Roman(n int) string {
+	return string(AppendRoman(nil, n))
+}
+
+// AppendRoman is Roman that appends the result to b
+func AppendRoman(b []byte, n int) []byte {
+	if n == 0 {
+		return append(b, 'N')
+	}
+	if n < 0 {
+		b = append(b, '-')
+		// do not negate n, which could overflow
+		for _, r := range romanNumerals {
+			for n <= -r.value {
+				b = append(b, r.symbol...)
+				n += r.value
+			}
+		}
+		return b
+	}
+	for _, r := range romanNumerals {
+		for n >= r.value {
+			b = append(b, r.symbol...)
+			n -= r.value
+		}
+	}
+	return b
+}
+
+func isRomanDigit(c byte) bool {
+	return strings.IndexByte("IVXLCDMivxlcdm", c) >= 0
+}
+
+// ParseRoman parses number in Roman numerals at start of s written the way
+// Roman writes it, in upper or lower case
+func ParseRoman(s string) (n int, nRunes int, err error) {
+	negative := len(s) > 0 && s[0] == '-'
+	if negative {
+		nRunes++
+	}
+	if nRunes < len(s) && (s[nRunes] == 'N' || s[nRunes] == 'n') && !negative {
+		if nRunes+1 == len(s) || !isRomanDigit(s[nRunes+1]) {
+			return 0, nRunes + 1, nil
+		}
+	}
+	start := nRunes
+	for nRunes < len(s) && isRomanDigit(s[nRunes]) {
+		nRunes++
+	}
+	if start == nRunes {
+		return 0, 0, fmt.Errorf("expected Roman numeral, got %q", s)
+	}
+	digits := strings.ToUpper(s[start:nRunes])
+	rest := digits
+	for _, r := range romanNumerals {
+		// at most three repetitions of I, X, C; more of M
+		for k := 0; strings.HasPrefix(rest, r.symbol) && (k < 3 || r.value == 1000); k++ {
+			n += r.value
+			rest = rest[len(r.symbol):]
+		}
+	}
+	if rest != "" || Roman(n) != digits {
+		return 0, 0, fmt.Errorf("invalid Roman numeral %q", s[start:nRunes])
+	}
+	if negative {
+		n = -n
+	}
+	return n, nRunes, nil
+}
@@ -0,0 +1,60 @@
+package format
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges are East Asian wide and fullwidth characters
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo initial consonants
+	{0x2E80, 0x303E},   // CJK radicals, Kangxi radicals, CJK symbols and punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK unified ideographs extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // fullwidth forms
+	{0xFFE0, 0xFFE6},   // fullwidth signs
+	{0x1F300, 0x1F64F}, // pictographs and emoticons
+	{0x1F900, 0x1F9FF}, // supplemental pictographs
+	{0x20000, 0x2FFFD}, // CJK unified ideographs extensions
+	{0x30000, 0x3FFFD},
+}
+
+// RuneWidth returns number of columns r takes when displayed: 0 for
+// combining marks and format characters, 2 for wide East Asian characters
+// and 1 for others
+func RuneWidth(r rune) int {
+	if r == 0 || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	for _, wide := range wideRanges {
+		if r < wide[0] {
+			break
+		}
+		if r <= wide[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// DisplayWidth returns number of columns s takes when displayed
+func DisplayWidth(s string) (width int) {
+	for _, r := range s {
+		width += RuneWidth(r)
+	}
+	return
+}
+
+func displayWidth(b []byte) (width int) {
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		width += RuneWidth(r)
+		b = b[size:]
+	}
+	return
+}
@@ -0,0 +1,37 @@
+package format_test
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"", 0},
+		{"Makara", 6},
+		{"Schütze", 7},
+		{"♂", 1},
+		{"ミーナ", 6},
+		{"木曜日", 6},
+		{"मकर", 3},
+		{"वृश्चिक", 5},
+		{"１２", 4},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, format.DisplayWidth(test.input), test.expected)
+	}
+}
+
+func TestModifierApplyWide(t *testing.T) {
+	m := format.Modifier{Width: 8}
+	assert.Equal(t, string(m.Apply([]byte("ミーナ"), 0)), "  ミーナ")
+	assert.Equal(t, string(m.Apply([]byte("वृश्चिक"), 0)), "   वृश्चिक")
+	assert.Equal(t, string(format.Modifier{Case: format.CaseUpper, Width: 9}.Apply([]byte("Schütze"), 0)), "  SCHÜTZE")
+}
@@ -1,9 +1,12 @@
 package planets
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"math/bits"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/HelloWorld-n/PlanetTime/format"
 )
@@ -20,7 +23,8 @@ const (
 	Sol      = Vinqua * 24
 )
 
-// Functions returning the slices, instead of global variables, to emulate immutability
+// Functions returning the slices, instead of global variables, to emulate immutability;
+// they hold names of DefaultLocale
 
 func (t MarsTime) LongWeekSolNames() []string {
 	return []string{
@@ -135,117 +139,154 @@ func (t MarsTime) SolsInMonth(month int) (sols int) {
 	return 28
 }
 
-func NewMarsTime(t *time.Time) (res MarsTime) {
-	ref := time.Date(1609, time.March, 11, 18, 40, 34, 0, time.UTC)
+// SolsInMonthOfRotation is SolsInMonth that also counts the leap Vrishika 28th
+func (t MarsTime) SolsInMonthOfRotation(rotation int, month int) (sols int) {
+	sols = t.SolsInMonth(month)
+	if month == 24 && t.RotationHasLeapVrishika28th(rotation) {
+		sols += 1
+	}
+	return
+}
 
-	duration := t.Sub(ref)
+// The leap rule of RotationHasLeapVrishika28th repeats every 500 rotations,
+// while months follow a fixed pattern of five 28-sol months and one 27-sol
+// month; both allow to convert between sols and calendar in constant time.
+const (
+	solsInCommonRotation = 668
+	rotationsInLeapCycle = 500
+	leapRotationsInCycle = rotationsInLeapCycle/2 + rotationsInLeapCycle/10 - rotationsInLeapCycle/100 + rotationsInLeapCycle/500
+	solsInLeapCycle      = rotationsInLeapCycle*solsInCommonRotation + leapRotationsInCycle
+	monthsInMonthCycle   = 6
+	solsInLongMonth      = 28
+	solsInMonthCycle     = monthsInMonthCycle*solsInLongMonth - 1
+)
 
-	// t.Sub is not able to go more than 2562047h47m16.854775807s
-	largeDuration := 100000 * Sol
-	for duration > largeDuration {
-		ref = ref.Add(largeDuration)
-		res.TotalSols += int(largeDuration / Sol)
-		duration = t.Sub(ref)
-	}
+// ceilDiv returns a/b rounded towards positive infinity
+func ceilDiv(a int, b int) int {
+	quo, _ := floorDiv(-a, b)
+	return -quo
+}
 
-	res = MarsTime{
-		TotalSols:            res.TotalSols + int(duration/Sol),
-		DurationOfCurrentSol: duration % Sol,
-	}
+// leapRotationsBefore returns number of leap rotations in [0, rotation),
+// negated for number of those in [rotation, 0) when rotation is negative
+func leapRotationsBefore(rotation int) int {
+	odd, _ := floorDiv(rotation, 2)
+	return odd + ceilDiv(rotation, 10) - ceilDiv(rotation, 100) + ceilDiv(rotation, 500)
+}
 
-	return res
+// solsBeforeRotation returns TotalSols of the first sol of rotation
+func (t MarsTime) solsBeforeRotation(rotation int) (sols int) {
+	return solsInCommonRotation*rotation + leapRotationsBefore(rotation)
 }
 
-func (t MarsTime) ExampleToLayout(example string) (layout string) {
-	replacements := map[string]string{
-		"203": "%R",
-
-		"Makara": "%NM",
-		"Mak":    "%nM",
-		"04":     "%0M",
-		"_4":     "%_M",
-		"4":      "%M",
-		"13":     "%0W",
-		"!13":    "%W",
-
-		"Jovis": "%NS",
-		"Jov":   "%nS",
-		"05":    "%0S",
-		"_5":    "%_S",
-		"5":     "%S",
-		"5th":   "%oS",
-
-		"A.":  "%Vu.",
-		"a.":  "%Vl.",
-		"A00": "%Vu%0V11",
-		"00":  "%0V",
-		"_0":  "%_V",
-		"0":   "%V",
-		"12":  "%V12",
-
-		"01": "%0L",
-		"_1": "%_L",
-		"1":  "%L",
-
-		"02":        "%0F",
-		"_2":        "%_F",
-		"2":         "%F",
-		"3":         "%f",
-		"300000000": "%f0",
-	}
-	isWeekAdded := false
-
-	for len(example) > 0 {
-		matched := false
-		addLayout := ""
-		removeExample := ""
-		for k, v := range replacements {
-			if len(removeExample) > len(k) {
-				continue
-			}
-			if strings.HasPrefix(example, k) {
-				addLayout = v
-				removeExample = k
-				matched = true
-			}
-		}
-		if matched {
-			example = example[len(removeExample):]
-			layout += addLayout + "%'"
-			if addLayout == "%W" || addLayout == "%0W" || addLayout == "%_W" {
-				isWeekAdded = true
-			}
-		} else {
-			if example[0] == '%' {
-				layout += "%%"
-				example = example[1:]
-			} else {
-				layout += string(example[0])
-				example = example[1:]
-			}
-		}
+// solsBeforeMonth returns number of sols in rotation preceding month
+func (t MarsTime) solsBeforeMonth(month int) (sols int) {
+	return (month-1)*solsInLongMonth - (month-1)/monthsInMonthCycle
+}
+
+// rotationOfSol returns rotation containing given TotalSols
+func (t MarsTime) rotationOfSol(totalSols int) (rotation int) {
+	cycle, solOfCycle := floorDiv(totalSols, solsInLeapCycle)
+	rotation = cycle*rotationsInLeapCycle + solOfCycle*rotationsInLeapCycle/solsInLeapCycle
+	// estimate above is off by at most one rotation
+	for t.solsBeforeRotation(rotation) > totalSols {
+		rotation -= 1
+	}
+	for t.solsBeforeRotation(rotation+1) <= totalSols {
+		rotation += 1
 	}
+	return
+}
+
+// Conversion between Earth and Mars works on Unix seconds and nanoseconds
+// instead of time.Duration, which can only span about 292 years.
+// 312500 sols are exactly 27742263777 seconds, so seconds are first split
+// into such cycles and only the remainder is converted in nanoseconds,
+// using 128-bit arithmetic as it may exceed int64.
+// The epoch is split into cycles too, so that Unix seconds near either end
+// of int64 are converted without overflow.
+var (
+	marsEpochUnix                  = time.Date(1609, time.March, 11, 18, 40, 34, 0, time.UTC).Unix()
+	marsEpochCycles, marsEpochSecs = floorDiv(int(marsEpochUnix), secondsInConversionCycle)
+)
 
-	if isWeekAdded {
-		layout = strings.ReplaceAll(layout, "%S", "%WS")
+const (
+	solsInConversionCycle    = 312_500
+	secondsInConversionCycle = 27_742_263_777
+)
+
+// NewMarsTime converts Earth time to Mars time. The conversion is exact:
+// for every t whose Unix time fits int64, NewMarsTime(&t).Time() equals t
+// (in UTC and without monotonic clock reading).
+func NewMarsTime(t *time.Time) (res MarsTime) {
+	cycles, secs := floorDiv(int(t.Unix()), secondsInConversionCycle)
+	cycles -= marsEpochCycles
+	secs -= marsEpochSecs
+	if secs < 0 {
+		cycles--
+		secs += secondsInConversionCycle
+	}
+
+	hi, lo := bits.Mul64(uint64(secs), uint64(time.Second))
+	lo, carry := bits.Add64(lo, uint64(t.Nanosecond()), 0)
+	sols, rem := bits.Div64(hi+carry, lo, uint64(Sol))
+
+	return MarsTime{
+		TotalSols:            cycles*solsInConversionCycle + int(sols),
+		DurationOfCurrentSol: time.Duration(rem),
 	}
+}
 
-	return layout
+// MarsDate returns the MarsTime of given calendar components, where
+// nanofragment is expressed in billionths of fragment.
+// Like time.Date, values outside of their usual ranges are normalized,
+// so sol 29 of Sagittarius becomes sol 1 of Dhanus and month 25 becomes
+// Sagittarius of the following rotation.
+func MarsDate(rotation int, month int, sol int, vinqua int, layer int, fragment int, nanofragment int) MarsTime {
+	var carry int
+	carry, nanofragment = floorDiv(nanofragment, int(time.Second))
+	fragment += carry
+	carry, fragment = floorDiv(fragment, 60)
+	layer += carry
+	carry, layer = floorDiv(layer, 60)
+	vinqua += carry
+	carry, vinqua = floorDiv(vinqua, 24)
+	sol += carry
+	rotation, month = normalizeRotationMonth(rotation, month)
+
+	var t MarsTime
+	t.TotalSols = t.solsBeforeRotation(rotation) + t.solsBeforeMonth(month) + sol - 1
+	t.DurationOfCurrentSol = time.Duration(vinqua)*Vinqua +
+		time.Duration(layer)*Layer +
+		time.Duration(fragment)*Fragment +
+		// round up so that Params gives back the same nanofragment
+		(time.Duration(nanofragment)*Fragment+time.Second-1)/time.Second
+	return t
 }
 
 func (t MarsTime) Params() (rotation int, month int, sol int, vinqua int, layer int, fragment int, rem int) {
-	month = 1
-	sol = t.TotalSols
-	for sol >= t.SolsInRotation(rotation) {
-		sol -= t.SolsInRotation(rotation)
-		rotation += 1
-	}
-	for sol >= t.SolsInMonth(month) {
-		sol -= t.SolsInMonth(month)
-		month += 1
+	rotation, month, sol = t.date()
+	vinqua, layer, fragment, rem = t.clock()
+	return
+}
+
+func (t MarsTime) date() (rotation int, month int, sol int) {
+	t = t.normalize()
+	rotation = t.rotationOfSol(t.TotalSols)
+	solOfRotation := t.TotalSols - t.solsBeforeRotation(rotation)
+	if solOfRotation == solsInCommonRotation {
+		// leap Vrishika 28th
+		month, sol = 24, 28
+	} else {
+		monthCycle, solOfMonthCycle := floorDiv(solOfRotation, solsInMonthCycle)
+		month = monthCycle*monthsInMonthCycle + solOfMonthCycle/solsInLongMonth + 1
+		sol = solOfMonthCycle%solsInLongMonth + 1
 	}
-	sol += 1
+	return
+}
 
+func (t MarsTime) clock() (vinqua int, layer int, fragment int, rem int) {
+	t = t.normalize()
 	vinqua = int(t.DurationOfCurrentSol / Vinqua)
 	layer = int(t.DurationOfCurrentSol % Vinqua / Layer)
 	fragment = int(t.DurationOfCurrentSol % Layer / Fragment)
@@ -253,121 +294,119 @@ func (t MarsTime) Params() (rotation int, month int, sol int, vinqua int, layer
 	return
 }
 
+// FormatError reports a layout fragment that is not a known token
+type FormatError struct {
+	Layout   string
+	Offset   int // byte offset of Fragment within Layout
+	Column   int // offset of Fragment within Layout in characters (runes)
+	Fragment string
+	// Err is set when Fragment is a token with an invalid modifier
+	Err error
+}
+
+func (e *FormatError) Error() string {
+	if e.Err != nil {
+		return `fragment "` + e.Fragment + `" not recognized: ` + e.Err.Error()
+	}
+	return (`` +
+		`fragment "` + e.Fragment + `" not recognized: ` +
+		`use "%%" for literal "%" and ` +
+		`use "%'" to avoid conflict with possible future update ` +
+		`for example use "%V%'E" when you want vinqua followed by "E" so that "%VE" can be used in future`)
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// Format returns t formatted by layout; see FormatE. For unknown tokens
+// the result is a description of the error prefixed with "error: ".
 func (t MarsTime) Format(layout string) (res string) {
-	rotation, month, sol, vinqua, layer, fragment, rem := t.Params()
-	week := (4 * (month - 1)) + (sol-1)/7 + 1
-	weekSol := sol % 7
-	if weekSol == 0 {
-		weekSol = 7
-	}
-
-	longSol := t.LongWeekSolNames()[weekSol-1]
-	shortSol := t.ShortWeekSolNames()[weekSol-1]
-	longMonth := t.LongMonthNames()[month-1]
-	shortMonth := t.ShortMonthNames()[month-1]
-
-	vinquaLowercase := "a"
-	vinquaUppercase := "A"
-	if vinqua >= 12 {
-		vinquaLowercase = "p"
-		vinquaUppercase = "P"
-	}
-	vinqua11 := vinqua % 12
-	vinqua12 := vinqua11
-	if vinqua11 == 0 {
-		vinqua12 = 12
-	}
-
-	replacements := map[string]string{
-		"%R":    format.Iota(rotation),
-		"%M":    format.Iota(month),
-		"%0M":   format.Pad2(month),
-		"%_M":   format.PadSpace(month),
-		"%nM":   shortMonth,
-		"%NM":   longMonth,
-		"%S":    format.Iota(sol),
-		"%oS":   format.Ordinal(sol),
-		"%0S":   format.Pad2(sol),
-		"%_S":   format.PadSpace(sol),
-		"%D":    format.Iota(sol),
-		"%oD":   format.Ordinal(sol),
-		"%0D":   format.Pad2(sol),
-		"%_D":   format.PadSpace(sol),
-		"%w":    format.Iota(week),
-		"%0W":   format.Pad2(week),
-		"%_W":   format.PadSpace(week),
-		"%W":    format.Iota(week),
-		"%WS":   format.Iota(weekSol),
-		"%NS":   longSol,
-		"%nS":   shortSol,
-		"%WD":   format.Iota(weekSol),
-		"%ND":   longSol,
-		"%nD":   shortSol,
-		"%V":    format.Iota(vinqua),
-		"%0V":   format.Pad2(vinqua),
-		"%_V":   format.PadSpace(vinqua),
-		"%V11":  format.Iota(vinqua11),
-		"%0V11": format.Pad2(vinqua11),
-		"%_V11": format.PadSpace(vinqua11),
-		"%V12":  format.Iota(vinqua12),
-		"%0V12": format.Pad2(vinqua12),
-		"%_V12": format.PadSpace(vinqua12),
-		"%Vl":   vinquaLowercase,
-		"%Vu":   vinquaUppercase,
-		"%L":    format.Iota(layer),
-		"%0L":   format.Pad2(layer),
-		"%_L":   format.PadSpace(layer),
-		"%F":    format.Iota(fragment),
-		"%0F":   format.Pad2(fragment),
-		"%_F":   format.PadSpace(fragment),
-		"%f":    format.RemoveZeroesFromDecimalPortionOfNumber(format.Pad9(rem)),
-		"%f0":   format.Pad9(rem),
-		"%%":    "%",
-	}
-
-	var builder strings.Builder
+	res, err := t.FormatE(layout)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return res
+}
+
+// FormatE returns t formatted by layout, or *FormatError if layout
+// contains an unknown token
+func (t MarsTime) FormatE(layout string) (res string, err error) {
+	b, err := t.AppendFormat(make([]byte, 0, len(layout)+16), layout)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// AppendFormat is FormatE that appends the result to b; on error, b is
+// returned unchanged
+func (t MarsTime) AppendFormat(b []byte, layout string) ([]byte, error) {
+	return t.appendFormat(b, layout, builtinToken, DefaultLocale)
+}
+
+func (t MarsTime) appendFormat(b []byte, layout string, lookup tokenLookup, locale *Locale) ([]byte, error) {
+	f := t.formatFields(usesDate | usesClock)
+	f.locale = locale
+	start := len(b)
 	for i := 0; i < len(layout); {
 		if layout[i] == '%' {
-			matched := false
-			for length := 5; length > 1; length-- {
-				if i+length <= len(layout) {
-					token := layout[i : i+length]
-					if token == "%'" {
-						matched = true
-						i += 2
-						break
-					}
-					if val, ok := replacements[token]; ok {
-						builder.WriteString(val)
-						i += length
-						matched = true
-						break
-					}
-				}
-			}
-			if !matched {
-				end := i + 1
-				for end < len(layout) && end < i+4 {
-					c := layout[end]
-					if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' {
-						end++
-					} else {
-						break
-					}
-				}
-				fragment := layout[i:end]
-				return (`` +
-					`error: fragment "` + fragment + `" not recognized: ` +
-					`use "%%" for literal "%" and ` +
-					`use "%'" to avoid conflict with possible future update ` +
-					`for example use "%V%'E" when you want vinqua followed by "E" so that "%VE" can be used in future`)
+			def, err := scanToken(layout, i, lookup)
+			if err != nil {
+				return b[:start], err
 			}
+			b = def.format(f, b)
+			i += len(def.token)
 		} else {
-			builder.WriteByte(layout[i])
+			b = append(b, layout[i])
 			i++
 		}
 	}
-	return builder.String()
+	return b, nil
+}
+
+type formatFields struct {
+	t      MarsTime
+	locale *Locale
+
+	rotation int
+	month    int
+	sol      int
+	week     int
+	weekSol  int
+	vinqua   int
+	vinqua11 int
+	vinqua12 int
+	layer    int
+	fragment int
+	rem      int
+}
+
+// fieldUse tells which groups of formatFields are needed
+type fieldUse uint8
+
+const (
+	usesDate fieldUse = 1 << iota
+	usesClock
+)
+
+func (t MarsTime) formatFields(uses fieldUse) (f formatFields) {
+	f.t = t
+	f.locale = DefaultLocale
+	if uses&usesDate != 0 {
+		f.rotation, f.month, f.sol = t.date()
+		f.week = 4*(f.month-1) + (f.sol-1)/7 + 1
+		f.weekSol = (f.sol-1)%7 + 1
+	}
+	if uses&usesClock != 0 {
+		f.vinqua, f.layer, f.fragment, f.rem = t.clock()
+		f.vinqua11 = f.vinqua % 12
+		f.vinqua12 = f.vinqua11
+		if f.vinqua11 == 0 {
+			f.vinqua12 = 12
+		}
+	}
+	return
 }
 
 func (t MarsTime) FormatExample(example string) (res string) {
@@ -375,193 +414,212 @@ func (t MarsTime) FormatExample(example string) (res string) {
 	return t.Format(layout)
 }
 
-func (t MarsTime) Parse(layout string, input string) (mt MarsTime, err error) {
-	var (
-		rotation int
-		month    int
-		sol      int
-		vinqua   int
-		layer    int
-		fragment int
-		rem      int
-
-		week    int
-		weekSol int
-	)
-
-	vinquaRequiresAMPM := false
-	vinquaFullfillsAMPM := false
-	i, j := 0, 0
-	for i < len(layout) {
-		if layout[i] == '%' {
-			if i+2 <= len(layout) && layout[i:i+2] == "%'" {
-				i += 2
-				continue
-			}
-			var token string
-			found := false
-			maxTokenLen := 5
-			if i+maxTokenLen > len(layout) {
-				maxTokenLen = len(layout) - i
-			}
-			for l := maxTokenLen; l >= 2; l-- {
-				token = layout[i : i+l]
-				if validToken(token) {
-					i += l
-					found = true
-					break
-				}
-			}
-			if !found {
-				fragEnd := i + 1
-				for fragEnd < len(layout) && fragEnd < i+maxTokenLen {
-					fragEnd++
-				}
-				return MarsTime{}, fmt.Errorf(`error: fragment "%s" not recognized: use "%%%%" for literal "%%" and use "%%'" to avoid conflict with possible future update`, layout[i:fragEnd])
-			}
+var (
+	ErrLiteralMismatch  = errors.New("input does not match layout literal")
+	ErrTrailingInput    = errors.New("input not fully consumed")
+	ErrInsufficientData = errors.New("insufficient data to reconstruct MarsTime")
+	ErrMissingAMPM      = errors.New("vinqua requires AM/PM specification but not provided")
+	ErrOutOfRange       = errors.New("value out of range")
+	ErrInconsistent     = errors.New("values are inconsistent")
+)
 
-			var value int
-			var consumed int
-			var parseErr error
-
-			switch token {
-			case
-				"%R",
-				"%M", "%0M", "%_M",
-				"%S", "%0S", "%_S",
-				"%D", "%0D", "%_D",
-				"%V", "%0V", "%_V",
-				"%L", "%0L", "%_L",
-				"%V11", "%0V11", "%_V11",
-				"%V12", "%0V12", "%_V12",
-				"%F", "%0F", "%_F", "%f0",
-				"%w", "%0W", "%_W", "%W",
-				"%WS", "%wS", "%WD", "%wD":
-				value, consumed, parseErr = format.ParseNumeric(input[j:])
-				if parseErr != nil {
-					return MarsTime{}, fmt.Errorf("token %q: %v", token, parseErr)
-				}
-				if token == "%V12" || token == "%_V12" || token == "%0V12" {
-					vinquaRequiresAMPM = true
-					for value >= 12 {
-						value -= 12
-					}
-				}
-
-			case "%f":
-				value, consumed, parseErr = format.ParseDecimal(input[j:], 9)
-			case "%Vl", "%Vu":
-				value, consumed, parseErr = 0, 1, nil
-				vinquaFullfillsAMPM = true
-				switch input[j] {
-				case 'a', 'A':
-					value = 0
-				case 'p', 'P':
-					value = 12
-				default:
-					return MarsTime{}, fmt.Errorf("expected element of {'a', 'A', 'p', 'P'} for token %q at position %d in input", token, j)
-				}
-			case "%oS", "%oD":
-				value, consumed, parseErr = format.ParseNumeric(input[j:])
-				if parseErr != nil {
-					return MarsTime{}, fmt.Errorf("token %q: %v", token, parseErr)
-				}
-				consumed += 2
-			case "%%":
-				if j >= len(input) || input[j] != '%' {
-					return MarsTime{}, fmt.Errorf("expected literal '%%' at position %d in input", j)
-				}
-				consumed = 1
-			case "%NM":
-				value, consumed, parseErr = t.ParseMonthName(input[j:], true)
-				if parseErr != nil {
-					return MarsTime{}, fmt.Errorf("token %q: %v", token, parseErr)
-				}
-			case "%nM":
-				value, consumed, parseErr = t.ParseMonthName(input[j:], false)
-				if parseErr != nil {
-					return MarsTime{}, fmt.Errorf("token %q: %v", token, parseErr)
-				}
-			case "%NS", "%ND":
-				value, consumed, parseErr = t.ParseWeekSolName(input[j:], true)
-				if parseErr != nil {
-					return MarsTime{}, fmt.Errorf("token %q: %v", token, parseErr)
-				}
-			case "%nS", "%nD":
-				value, consumed, parseErr = t.ParseWeekSolName(input[j:], false)
-				if parseErr != nil {
-					return MarsTime{}, fmt.Errorf("token %q: %v", token, parseErr)
-				}
-			}
-			j += consumed
-
-			switch token {
-			case "%R":
-				rotation = value
-			case "%M", "%0M", "%_M", "%NM", "%nM":
-				month = value
-			case "%S", "%0S", "%_S", "%oS", "%D", "%0D", "%_D", "%oD":
-				sol = value
-			case "%V", "%0V", "%_V", "%V11", "%0V11", "%_V11", "%V12", "%0V12", "%_V12", "%Vl", "%Vu":
-				vinqua += value
-			case "%L", "%0L", "%_L":
-				layer = value
-			case "%F", "%0F", "%_F":
-				fragment = value
-			case "%f", "%f0":
-				rem = value*int(Fragment)/int(time.Second) + 1
-			case "%w", "%WS", "%nS", "%NS", "%WD", "%nD", "%ND":
-				weekSol = value
-			case "%W", "%0W", "%_W":
-				week = value
-			}
-		} else {
-			if j >= len(input) {
-				return MarsTime{}, fmt.Errorf("input not fully consumed, remaining: %q", input[j:])
-			}
-			if layout[i] != input[j] {
-				return MarsTime{}, fmt.Errorf("literal mismatch at layout[%d]=%q vs input[%d]=%q", i, layout[i], j, input[j])
-			}
-			i++
-			j++
-		}
+// ParseError describes why Parse failed; Err is *FormatError when the
+// layout itself is invalid and a description of bad input otherwise
+type ParseError struct {
+	Layout       string
+	Input        string
+	LayoutOffset int    // byte offset within Layout where parsing failed
+	InputOffset  int    // byte offset within Input where parsing failed
+	LayoutColumn int    // LayoutOffset in characters (runes)
+	InputColumn  int    // InputOffset in characters (runes)
+	Token        string // token being parsed or whose value failed a check, empty for literals and whole-input checks
+	Err          error
+}
+
+func (e *ParseError) Error() string {
+	where := fmt.Sprintf("parsing %q as %q at input[%d]", e.Input, e.Layout, e.InputOffset)
+	if e.InputColumn != e.InputOffset {
+		where += fmt.Sprintf(" (column %d)", e.InputColumn)
 	}
-	if j != len(input) {
-		return MarsTime{}, fmt.Errorf("input not fully consumed, remaining: %q", input[j:])
+	if e.Token != "" {
+		where += fmt.Sprintf(" with token %q", e.Token)
 	}
+	return where + ": " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parse parses input formatted by layout; every error is *ParseError
+func (t MarsTime) Parse(layout string, input string) (mt MarsTime, err error) {
+	return t.ParseWithOptions(layout, input, ParseOptions{})
+}
+
+// layoutParseError wraps error of CompileLayout into *ParseError
+func layoutParseError(layout string, input string, err error) *ParseError {
+	formatErr := err.(*FormatError)
+	return &ParseError{
+		Layout:       layout,
+		Input:        input,
+		LayoutOffset: formatErr.Offset,
+		LayoutColumn: formatErr.Column,
+		Token:        formatErr.Fragment,
+		Err:          err,
+	}
+}
+
+// parseState collects values of parsed tokens
+type parseState struct {
+	rotation int
+	month    int
+	sol      int
+	vinqua   int
+	layer    int
+	fragment int
+	rem      int
+
+	week    int
+	weekSol int
+
+	set                 parsedField
+	vinquaRequiresAMPM  bool
+	vinquaFullfillsAMPM bool
+
+	// at is position of token being parsed; positions of tokens that set
+	// values checked by validate are kept for its errors
+	at                                 tokenPosition
+	solAt, vinquaAt, weekAt, weekSolAt tokenPosition
+
+	opts ParseOptions
+}
+
+// tokenPosition is where token was in layout and input
+type tokenPosition struct {
+	layoutOffset int
+	inputOffset  int
+	token        string
+}
+
+// parsedField tells which values were present in parsed input
+type parsedField uint16
+
+const (
+	fieldRotation parsedField = 1 << iota
+	fieldMonth
+	fieldSol
+	fieldVinqua
+	fieldLayer
+	fieldFragment
+	fieldNanofragment
+	fieldWeek
+	fieldWeekSol
+)
 
-	if month == 0 && sol == 0 && week > 0 && weekSol > 0 {
-		month = (week-1)/4 + 1
-		weekIndex := (week - 1) % 4
-		sol = 7*weekIndex + weekSol
+// parseToken parses value of token at start of input, returning number of consumed bytes
+func (st *parseState) parseToken(def *tokenDef, input string) (consumed int, err error) {
+	value, consumed, err := def.parse(st, def, input)
+	if err != nil {
+		return 0, err
+	}
+
+	st.set |= def.field
+	switch def.field {
+	case fieldRotation:
+		st.rotation = value
+	case fieldMonth:
+		st.month = value
+	case fieldSol:
+		st.sol = value
+		st.solAt = st.at
+	case fieldVinqua:
+		st.vinqua += value
+		st.vinquaAt = st.at
+	case fieldLayer:
+		st.layer = value
+	case fieldFragment:
+		st.fragment = value
+	case fieldNanofragment:
+		st.rem = value
+	case fieldWeekSol:
+		st.weekSol = value
+		st.weekSolAt = st.at
+	case fieldWeek:
+		st.week = value
+		st.weekAt = st.at
+	}
+	return consumed, nil
+}
+
+func checkRange(name string, value int, lo int, hi int) error {
+	if value < lo || value > hi {
+		return fmt.Errorf("%w: %s %d not in [%d, %d]", ErrOutOfRange, name, value, lo, hi)
 	}
+	return nil
+}
 
-	if rotation == 0 || month == 0 || sol == 0 {
-		return MarsTime{}, fmt.Errorf("insufficient data to reconstruct MarsTime (month: %d, sol: %d)", month, sol)
+// marsTime reconstructs MarsTime from parsed values,
+// checking them against the calendar; at is position of token
+// whose value is wrong, with empty token when none is known
+func (st *parseState) marsTime() (mt MarsTime, at tokenPosition, err error) {
+	if st.set&(fieldMonth|fieldSol) == 0 && st.set&fieldWeek != 0 && st.set&fieldWeekSol != 0 {
+		st.month = (st.week-1)/4 + 1
+		weekIndex := (st.week - 1) % 4
+		st.sol = 7*weekIndex + st.weekSol
+		st.solAt = st.weekSolAt
+		st.set |= fieldMonth | fieldSol
+	}
+
+	var nextWeekSol MarsWeekSol
+	if st.opts.Reference != nil {
+		if nextWeekSol, err = st.fillFromReference(*st.opts.Reference); err != nil {
+			return MarsTime{}, at, err
+		}
 	}
 
-	if vinquaRequiresAMPM && !vinquaFullfillsAMPM {
-		return MarsTime{}, fmt.Errorf("vinqua requires AM/PM specification but not provided; use token among {`%%V`, `%%0V`, `%%_V`} for vinqua parsing 00 thru 23")
+	if st.set&fieldRotation == 0 || st.set&fieldMonth == 0 || st.set&fieldSol == 0 {
+		return MarsTime{}, at, fmt.Errorf("%w (month: %d, sol: %d)", ErrInsufficientData, st.month, st.sol)
 	}
 
-	totalSols := 0
-	for r := 0; r < rotation; r++ {
-		totalSols += t.SolsInRotation(r)
+	if st.vinquaRequiresAMPM && !st.vinquaFullfillsAMPM {
+		return MarsTime{}, at, fmt.Errorf("%w; use token among {`%%V`, `%%0V`, `%%_V`} for vinqua parsing 00 thru 23", ErrMissingAMPM)
 	}
-	for m := 1; m < month; m++ {
-		totalSols += t.SolsInMonth(m)
+
+	if at, err = st.validate(); err != nil {
+		return MarsTime{}, at, err
 	}
-	totalSols += (sol - 1)
 
-	duration := time.Duration(vinqua)*Vinqua +
-		time.Duration(layer)*Layer +
-		time.Duration(fragment)*Fragment +
-		time.Duration(rem)
+	mt = MarsDate(st.rotation, st.month, st.sol, st.vinqua, st.layer, st.fragment, st.rem)
+	if nextWeekSol != 0 {
+		for mt.WeekSol() != nextWeekSol || mt.Before(*st.opts.Reference) {
+			mt = mt.AddSols(1)
+		}
+	}
+	return mt, at, nil
+}
 
-	return MarsTime{
-		TotalSols:            totalSols,
-		DurationOfCurrentSol: duration,
-	}, nil
+// validate checks ranges depending on several tokens and consistency
+// of week and weekSol with month and sol, returning position of token
+// that does not fit
+func (st *parseState) validate() (tokenPosition, error) {
+	t := MarsTime{}
+	solsInMonth := t.SolsInMonthOfRotation(st.rotation, st.month)
+	if st.sol > solsInMonth {
+		if st.month == 24 && st.sol == 28 {
+			return st.solAt, fmt.Errorf("%w: rotation %d has no leap Vrishika 28th", ErrOutOfRange, st.rotation)
+		}
+		return st.solAt, fmt.Errorf("%w: sol %d not in [1, %d] of %v", ErrOutOfRange, st.sol, solsInMonth, MarsMonth(st.month))
+	}
+	if err := checkRange("vinqua", st.vinqua, 0, 23); err != nil {
+		return st.vinquaAt, err
+	}
+	if weekSol := (st.sol-1)%7 + 1; st.weekSol != 0 && st.weekSol != weekSol {
+		return st.weekSolAt, fmt.Errorf("%w: %v %v is %v, not %v", ErrInconsistent, MarsMonth(st.month), format.Ordinal(st.sol), MarsWeekSol(weekSol), MarsWeekSol(st.weekSol))
+	}
+	if week := 4*(st.month-1) + (st.sol-1)/7 + 1; st.week != 0 && st.week != week {
+		return st.weekAt, fmt.Errorf("%w: %v %v is in week %d, not %d", ErrInconsistent, MarsMonth(st.month), format.Ordinal(st.sol), week, st.week)
+	}
+	return tokenPosition{}, nil
 }
 
 func (t MarsTime) ParseExample(example string, input string) (mt MarsTime, err error) {
@@ -569,101 +627,65 @@ func (t MarsTime) ParseExample(example string, input string) (mt MarsTime, err e
 	return t.Parse(layout, input)
 }
 
-func validToken(token string) bool {
-	valid := map[string]bool{
-		"%R":    true,
-		"%M":    true,
-		"%0M":   true,
-		"%_M":   true,
-		"%nM":   true,
-		"%NM":   true,
-		"%S":    true,
-		"%oS":   true,
-		"%0S":   true,
-		"%_S":   true,
-		"%D":    true,
-		"%oD":   true,
-		"%0D":   true,
-		"%_D":   true,
-		"%w":    true,
-		"%0W":   true,
-		"%_W":   true,
-		"%W":    true,
-		"%WS":   true,
-		"%NS":   true,
-		"%nS":   true,
-		"%WD":   true,
-		"%ND":   true,
-		"%nD":   true,
-		"%V":    true,
-		"%0V":   true,
-		"%_V":   true,
-		"%V11":  true,
-		"%0V11": true,
-		"%_V11": true,
-		"%V12":  true,
-		"%0V12": true,
-		"%_V12": true,
-		"%Vl":   true,
-		"%Vu":   true,
-		"%L":    true,
-		"%0L":   true,
-		"%_L":   true,
-		"%F":    true,
-		"%0F":   true,
-		"%_F":   true,
-		"%f":    true,
-		"%f0":   true,
-		"%%":    true,
-		"%'":    true,
-	}
-	return valid[token]
+// tokenLookup returns definition of token, nil if token is unknown
+type tokenLookup func(token string) *tokenDef
+
+func builtinToken(token string) *tokenDef {
+	return tokenDefs[token]
 }
 
-func (t MarsTime) ParseMonthName(s string, long bool) (n int, nameLen int, err error) {
-	var names []string
-	if long {
-		names = t.LongMonthNames()
-	} else {
-		names = t.ShortMonthNames()
+// scanToken returns the longest known token at layout[i], which is '%'
+func scanToken(layout string, i int, lookup tokenLookup) (def *tokenDef, err error) {
+	for length := 5; length > 1; length-- {
+		if i+length <= len(layout) {
+			if def := lookup(layout[i : i+length]); def != nil {
+				return def, nil
+			}
+		}
 	}
-	for i, name := range names {
-		if strings.HasPrefix(s, name) {
-			return i + 1, len(name), nil
+	column := utf8.RuneCountInString(layout[:i])
+	if def, end, err := modifiedToken(layout, i, lookup); err != nil {
+		return nil, &FormatError{Layout: layout, Offset: i, Column: column, Fragment: layout[i:end], Err: err}
+	} else if def != nil {
+		return def, nil
+	}
+	// fragment is '%' followed by up to three letters, digits or '_'
+	end := i + 1
+	for n := 0; n < 3 && end < len(layout); n++ {
+		r, size := utf8.DecodeRuneInString(layout[end:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			break
 		}
+		end += size
 	}
-	err = fmt.Errorf("no matching month name found in %q", s)
-	return
+	return nil, &FormatError{Layout: layout, Offset: i, Column: column, Fragment: layout[i:end]}
+}
+
+// ParseMonthName parses month name of DefaultLocale at start of s
+func (t MarsTime) ParseMonthName(s string, long bool) (n int, nameLen int, err error) {
+	return DefaultLocale.ParseMonthName(s, long)
 }
 
+// ParseWeekSolName parses weekSol name of DefaultLocale at start of s
 func (t MarsTime) ParseWeekSolName(s string, long bool) (n int, nameLen int, err error) {
-	var names []string
-	if long {
-		names = t.LongWeekSolNames()
-	} else {
-		names = t.ShortWeekSolNames()
-	}
-	for i, name := range names {
-		if strings.HasPrefix(s, name) {
-			return i + 1, len(name), nil
-		}
-	}
-	err = fmt.Errorf("no matching weekSol name found in %q", s)
-	return
+	return DefaultLocale.ParseWeekSolName(s, long)
 }
 
+// Time converts Mars time back to Earth time in UTC; see NewMarsTime.
+// The result is undefined if it does not fit into time.Time.
 func (mt MarsTime) Time() (result time.Time) {
-	result = time.Date(1609, time.March, 11, 18, 40, 34, 0, time.UTC)
+	mt = mt.normalize()
+	cycles, sols := floorDiv(mt.TotalSols, solsInConversionCycle)
 
-	// t.Sub is not able to go more than 2562047h47m16.854775807s
-	quof := 100000
-	for mt.TotalSols > quof {
-		fmt.Println(Sol * time.Duration(quof))
-		result = result.Add(Sol * time.Duration(quof))
-		mt.TotalSols -= quof
-	}
-	result = result.Add(Sol * time.Duration(mt.TotalSols))
-	result = result.Add(mt.DurationOfCurrentSol)
+	hi, lo := bits.Mul64(uint64(sols), uint64(Sol))
+	lo, carry := bits.Add64(lo, uint64(mt.DurationOfCurrentSol), 0)
+	secs, nsec := bits.Div64(hi+carry, lo, uint64(time.Second))
 
-	return
+	cycles += marsEpochCycles
+	unixSecs := int(secs) + marsEpochSecs
+	if unixSecs >= secondsInConversionCycle {
+		cycles++
+		unixSecs -= secondsInConversionCycle
+	}
+	return time.Unix(int64(cycles)*secondsInConversionCycle+int64(unixSecs), int64(nsec)).UTC()
 }
@@ -0,0 +1,141 @@
+package planets
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	minDuration time.Duration = math.MinInt64
+	maxDuration time.Duration = math.MaxInt64
+)
+
+// normalize moves whole sols out of DurationOfCurrentSol into TotalSols,
+// leaving 0 <= DurationOfCurrentSol < Sol
+func (t MarsTime) normalize() MarsTime {
+	t.TotalSols += int(t.DurationOfCurrentSol / Sol)
+	t.DurationOfCurrentSol %= Sol
+	if t.DurationOfCurrentSol < 0 {
+		t.DurationOfCurrentSol += Sol
+		t.TotalSols -= 1
+	}
+	return t
+}
+
+// Add returns the time t+d
+func (t MarsTime) Add(d time.Duration) MarsTime {
+	t = t.normalize()
+	// split d first so that adding it to DurationOfCurrentSol can not overflow
+	t.TotalSols += int(d / Sol)
+	t.DurationOfCurrentSol += d % Sol
+	return t.normalize()
+}
+
+// AddSols returns the time t shifted by n whole sols
+func (t MarsTime) AddSols(n int) MarsTime {
+	t = t.normalize()
+	t.TotalSols += n
+	return t
+}
+
+// Sub returns the duration t-u; like time.Time.Sub the result saturates
+// at the minimum or maximum time.Duration when it does not fit
+func (t MarsTime) Sub(u MarsTime) time.Duration {
+	t = t.normalize()
+	u = u.normalize()
+
+	sols := t.TotalSols - u.TotalSols
+	rem := t.DurationOfCurrentSol - u.DurationOfCurrentSol
+
+	maxSols := int(maxDuration / Sol)
+	switch {
+	case sols > maxSols:
+		return maxDuration
+	case sols < -maxSols:
+		return minDuration
+	}
+	d := time.Duration(sols) * Sol
+	res := d + rem
+	switch {
+	case rem > 0 && res < d:
+		return maxDuration
+	case rem < 0 && res > d:
+		return minDuration
+	}
+	return res
+}
+
+// Compare returns -1 if t is before u, 0 if they are equal and +1 if t is after u
+func (t MarsTime) Compare(u MarsTime) int {
+	t = t.normalize()
+	u = u.normalize()
+	switch {
+	case t.TotalSols < u.TotalSols:
+		return -1
+	case t.TotalSols > u.TotalSols:
+		return +1
+	case t.DurationOfCurrentSol < u.DurationOfCurrentSol:
+		return -1
+	case t.DurationOfCurrentSol > u.DurationOfCurrentSol:
+		return +1
+	}
+	return 0
+}
+
+// Before reports whether t is before u
+func (t MarsTime) Before(u MarsTime) bool {
+	return t.Compare(u) < 0
+}
+
+// After reports whether t is after u
+func (t MarsTime) After(u MarsTime) bool {
+	return t.Compare(u) > 0
+}
+
+// Equal reports whether t and u represent the same instant,
+// even when their DurationOfCurrentSol is not normalized
+func (t MarsTime) Equal(u MarsTime) bool {
+	return t.Compare(u) == 0
+}
+
+// floorDiv returns a/b rounded towards negative infinity together with
+// the matching non-negative remainder
+func floorDiv(a int, b int) (quo int, rem int) {
+	quo, rem = a/b, a%b
+	if rem < 0 {
+		quo -= 1
+		rem += b
+	}
+	return
+}
+
+// normalizeRotationMonth moves months outside of 1..24 into rotation
+func normalizeRotationMonth(rotation int, month int) (int, int) {
+	carry, month0 := floorDiv(month-1, 24)
+	return rotation + carry, month0 + 1
+}
+
+// AddDate returns t shifted by given rotations, months and sols, keeping
+// the time of sol. Like time.Time.AddDate, a sol that does not exist in the
+// resulting month overflows into the following month, so adding one month
+// to Aquarius 28th gives Pisces 1st and adding one rotation to a leap
+// Vrishika 28th gives Sagittarius 1st of the rotation after.
+func (t MarsTime) AddDate(rotations int, months int, sols int) MarsTime {
+	t = t.normalize()
+	rotation, month, sol, _, _, _, _ := t.Params()
+	t.TotalSols = MarsDate(rotation+rotations, month+months, sol+sols, 0, 0, 0, 0).TotalSols
+	return t
+}
+
+// AddDateClamped is AddDate that clamps sol to the last sol of the resulting
+// month before adding sols, so adding one month to Aquarius 28th gives
+// Kumbha 27th and adding one rotation to a leap Vrishika 28th gives
+// Vrishika 27th.
+func (t MarsTime) AddDateClamped(rotations int, months int, sols int) MarsTime {
+	t = t.normalize()
+	rotation, month, sol, _, _, _, _ := t.Params()
+	rotation, month = normalizeRotationMonth(rotation+rotations, month+months)
+	sol = min(sol, t.SolsInMonthOfRotation(rotation, month))
+	t.TotalSols = MarsDate(rotation, month, sol+sols, 0, 0, 0, 0).TotalSols
+	return t
+}
@@ -0,0 +1,132 @@
+package planets_test
+
+import (
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestMarsTimeAdd(t *testing.T) {
+	base := planets.MarsTime{TotalSols: 100, DurationOfCurrentSol: 20 * planets.Vinqua}
+	tests := []struct {
+		name     string
+		duration time.Duration
+		expected planets.MarsTime
+	}{
+		{"Zero", 0, planets.MarsTime{TotalSols: 100, DurationOfCurrentSol: 20 * planets.Vinqua}},
+		{"ThreeVinquas", 3 * planets.Vinqua, planets.MarsTime{TotalSols: 100, DurationOfCurrentSol: 23 * planets.Vinqua}},
+		{"OverflowIntoNextSol", 5 * planets.Vinqua, planets.MarsTime{TotalSols: 101, DurationOfCurrentSol: 1 * planets.Vinqua}},
+		{"ManySols", 10*planets.Sol + planets.Layer, planets.MarsTime{TotalSols: 110, DurationOfCurrentSol: 20*planets.Vinqua + planets.Layer}},
+		{"Backwards", -21 * planets.Vinqua, planets.MarsTime{TotalSols: 99, DurationOfCurrentSol: 23 * planets.Vinqua}},
+		{"BackwardsManySols", -2 * planets.Sol, planets.MarsTime{TotalSols: 98, DurationOfCurrentSol: 20 * planets.Vinqua}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, base.Add(tc.duration), tc.expected)
+		})
+	}
+	t.Run("NotNormalizedReceiver", func(t *testing.T) {
+		mt := planets.MarsTime{TotalSols: 5, DurationOfCurrentSol: 3*planets.Sol + planets.Vinqua}
+		assert.Equal(t, mt.Add(0), planets.MarsTime{TotalSols: 8, DurationOfCurrentSol: planets.Vinqua})
+	})
+	t.Run("AddSols", func(t *testing.T) {
+		assert.Equal(t, base.AddSols(3), planets.MarsTime{TotalSols: 103, DurationOfCurrentSol: 20 * planets.Vinqua})
+		assert.Equal(t, base.AddSols(-3), planets.MarsTime{TotalSols: 97, DurationOfCurrentSol: 20 * planets.Vinqua})
+	})
+}
+
+func TestMarsTimeSub(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		a := planets.MarsTime{TotalSols: 100, DurationOfCurrentSol: 20 * planets.Vinqua}
+		b := planets.MarsTime{TotalSols: 98, DurationOfCurrentSol: 22 * planets.Vinqua}
+		assert.Equal(t, a.Sub(b), planets.Sol+22*planets.Vinqua)
+		assert.Equal(t, b.Sub(a), -(planets.Sol + 22*planets.Vinqua))
+		assert.Equal(t, a.Sub(a), time.Duration(0))
+	})
+	t.Run("AddSubRoundTrip", func(t *testing.T) {
+		a := planets.MarsTime{TotalSols: 147908, DurationOfCurrentSol: 7 * planets.Layer}
+		d := 1234*planets.Sol + 17*planets.Fragment
+		assert.Equal(t, a.Add(d).Sub(a), d)
+	})
+	t.Run("Saturates", func(t *testing.T) {
+		a := planets.MarsTime{TotalSols: 1_000_000}
+		b := planets.MarsTime{TotalSols: -1_000_000}
+		assert.Equal(t, a.Sub(b), time.Duration(1<<63-1))
+		assert.Equal(t, b.Sub(a), time.Duration(-1<<63))
+	})
+}
+
+func TestMarsTimeCompare(t *testing.T) {
+	early := planets.MarsTime{TotalSols: 10, DurationOfCurrentSol: planets.Vinqua}
+	late := planets.MarsTime{TotalSols: 10, DurationOfCurrentSol: 2 * planets.Vinqua}
+	notNormalized := planets.MarsTime{TotalSols: 9, DurationOfCurrentSol: planets.Sol + planets.Vinqua}
+
+	t.Run("Compare", func(t *testing.T) {
+		assert.Equal(t, early.Compare(late), -1)
+		assert.Equal(t, late.Compare(early), 1)
+		assert.Equal(t, early.Compare(notNormalized), 0)
+	})
+	t.Run("BeforeAfterEqual", func(t *testing.T) {
+		assert.Equal(t, early.Before(late), true)
+		assert.Equal(t, early.After(late), false)
+		assert.Equal(t, late.After(early), true)
+		assert.Equal(t, early.Equal(notNormalized), true)
+		assert.Equal(t, early.Equal(late), false)
+	})
+	t.Run("Sorting", func(t *testing.T) {
+		times := []planets.MarsTime{late, {TotalSols: 11}, early, {TotalSols: 3}}
+		expected := []planets.MarsTime{{TotalSols: 3}, early, late, {TotalSols: 11}}
+
+		sorted := slices.Clone(times)
+		slices.SortFunc(sorted, planets.MarsTime.Compare)
+		assert.Equal(t, sorted, expected)
+
+		sorted = slices.Clone(times)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+		assert.Equal(t, sorted, expected)
+	})
+}
+
+func TestMarsTimeAddDate(t *testing.T) {
+	layout := planets.MarsISO
+	tests := []struct {
+		start     string
+		rotations int
+		months    int
+		sols      int
+		expected  string
+		clamped   string
+	}{
+		{"221=02=05T14|35|00", 0, 0, 0, "221=02=05T14|35|00", "221=02=05T14|35|00"},
+		{"221=02=05T14|35|00", 0, 1, 0, "221=03=05T14|35|00", "221=03=05T14|35|00"},
+		{"221=02=05T14|35|00", 1, 0, 0, "222=02=05T14|35|00", "222=02=05T14|35|00"},
+		{"221=02=05T14|35|00", 0, 25, 0, "222=03=05T14|35|00", "222=03=05T14|35|00"},
+		{"221=01=05T14|35|00", 0, -1, 0, "220=24=05T14|35|00", "220=24=05T14|35|00"},
+		{"221=01=05T14|35|00", 0, -25, 0, "219=24=05T14|35|00", "219=24=05T14|35|00"},
+		{"221=02=05T14|35|00", 0, 0, 30, "221=03=07T14|35|00", "221=03=07T14|35|00"},
+		{"221=02=05T14|35|00", 0, 0, -5, "221=01=28T14|35|00", "221=01=28T14|35|00"},
+
+		// Kumbha has only 27 sols
+		{"221=05=28T01|02|03", 0, 1, 0, "221=07=01T01|02|03", "221=06=27T01|02|03"},
+		{"221=05=28T01|02|03", 0, 1, 1, "221=07=02T01|02|03", "221=07=01T01|02|03"},
+
+		// 221 has the leap Vrishika 28th, while 222 does not
+		{"221=24=27T00|00|00", 0, 0, 1, "221=24=28T00|00|00", "221=24=28T00|00|00"},
+		{"221=24=28T00|00|00", 0, 0, 1, "222=01=01T00|00|00", "222=01=01T00|00|00"},
+		{"221=24=28T00|00|00", 1, 0, 0, "223=01=01T00|00|00", "222=24=27T00|00|00"},
+		{"221=24=28T00|00|00", -1, 0, 0, "220=24=28T00|00|00", "220=24=28T00|00|00"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.start, func(t *testing.T) {
+			start, err := planets.MarsTime{}.Parse(layout, tc.start)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, start.AddDate(tc.rotations, tc.months, tc.sols).Format(layout), tc.expected)
+			assert.Equal(t, start.AddDateClamped(tc.rotations, tc.months, tc.sols).Format(layout), tc.clamped)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package planets
+
+import "strconv"
+
+// MarsMonth specifies a month of the rotation (Sagittarius = 1, ...)
+type MarsMonth int
+
+const (
+	Sagittarius MarsMonth = 1 + iota
+	Dhanus
+	Capricornus
+	Makara
+	Aquarius
+	Kumbha
+	Pisces
+	Mina
+	Aries
+	Mesha
+	Taurus
+	Vrishabha
+	Gemini
+	Mithuna
+	Cancer
+	Karka
+	Leo
+	Simha
+	Virgo
+	Kanya
+	Libra
+	Tula
+	Scorpio
+	Vrishika
+)
+
+// String returns the full name of the month, such as "Makara"
+func (m MarsMonth) String() string {
+	if Sagittarius <= m && m <= Vrishika {
+		return MarsTime{}.LongMonthNames()[m-1]
+	}
+	return "%!MarsMonth(" + strconv.Itoa(int(m)) + ")"
+}
+
+// MarsWeekSol specifies a sol of the week (Solis = 1, ...)
+type MarsWeekSol int
+
+const (
+	Solis MarsWeekSol = 1 + iota
+	Lunae
+	Martis
+	Mercurii
+	Jovis
+	Veneris
+	Saturni
+)
+
+// String returns the full name of the weekSol, such as "Jovis"
+func (w MarsWeekSol) String() string {
+	if Solis <= w && w <= Saturni {
+		return MarsTime{}.LongWeekSolNames()[w-1]
+	}
+	return "%!MarsWeekSol(" + strconv.Itoa(int(w)) + ")"
+}
+
+// Rotation returns the rotation in which t occurs
+func (t MarsTime) Rotation() int {
+	rotation, _, _ := t.date()
+	return rotation
+}
+
+// Month returns the month of the rotation in which t occurs
+func (t MarsTime) Month() MarsMonth {
+	_, month, _ := t.date()
+	return MarsMonth(month)
+}
+
+// Sol returns the sol of the month in which t occurs, starting at 1
+func (t MarsTime) Sol() int {
+	_, _, sol := t.date()
+	return sol
+}
+
+// SolOfRotation returns the sol of the rotation in which t occurs,
+// in range [1, 668] for common rotations and [1, 669] for leap rotations
+func (t MarsTime) SolOfRotation() int {
+	t = t.normalize()
+	return t.TotalSols - t.solsBeforeRotation(t.rotationOfSol(t.TotalSols)) + 1
+}
+
+// Week returns the week of the rotation in which t occurs, in range [1, 96];
+// every month has four weeks starting on its 1st, 8th, 15th and 22nd sol
+func (t MarsTime) Week() int {
+	_, month, sol := t.date()
+	return 4*(month-1) + (sol-1)/7 + 1
+}
+
+// WeekSol returns the sol of the week in which t occurs
+func (t MarsTime) WeekSol() MarsWeekSol {
+	_, _, sol := t.date()
+	return MarsWeekSol((sol-1)%7 + 1)
+}
+
+// Clock returns the vinqua, layer and fragment within the sol of t
+func (t MarsTime) Clock() (vinqua int, layer int, fragment int) {
+	vinqua, layer, fragment, _ = t.clock()
+	return
+}
+
+// NanoFragment returns the billionths of fragment within the fragment of t
+func (t MarsTime) NanoFragment() int {
+	_, _, _, rem := t.clock()
+	return rem
+}
@@ -0,0 +1,80 @@
+package planets_test
+
+import (
+	"fmt"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestMarsMonth(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, planets.Sagittarius.String(), "Sagittarius")
+		assert.Equal(t, planets.Makara.String(), "Makara")
+		assert.Equal(t, planets.Vrishika.String(), "Vrishika")
+		assert.Equal(t, fmt.Sprint(planets.Mina), "Mina")
+		assert.Equal(t, planets.MarsMonth(25).String(), "%!MarsMonth(25)")
+	})
+	t.Run("MatchesNames", func(t *testing.T) {
+		for i, name := range (planets.MarsTime{}).LongMonthNames() {
+			assert.Equal(t, planets.MarsMonth(i+1).String(), name)
+		}
+	})
+}
+
+func TestMarsWeekSol(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, planets.Solis.String(), "Solis")
+		assert.Equal(t, planets.Jovis.String(), "Jovis")
+		assert.Equal(t, planets.Saturni.String(), "Saturni")
+		assert.Equal(t, planets.MarsWeekSol(0).String(), "%!MarsWeekSol(0)")
+	})
+	t.Run("MatchesNames", func(t *testing.T) {
+		for i, name := range (planets.MarsTime{}).LongWeekSolNames() {
+			assert.Equal(t, planets.MarsWeekSol(i+1).String(), name)
+		}
+	})
+}
+
+func TestMarsTimeAccessors(t *testing.T) {
+	t.Run("Mina26th", func(t *testing.T) {
+		marsTime := planets.MarsDate(221, 8, 26, 14, 35, 7, 5)
+		assert.Equal(t, marsTime.Rotation(), 221)
+		assert.Equal(t, marsTime.Month(), planets.Mina)
+		assert.Equal(t, marsTime.Sol(), 26)
+		assert.Equal(t, marsTime.SolOfRotation(), 7*28-1+26)
+		assert.Equal(t, marsTime.Week(), 32)
+		assert.Equal(t, marsTime.WeekSol(), planets.Jovis)
+		vinqua, layer, fragment := marsTime.Clock()
+		assert.Equal(t, [3]int{vinqua, layer, fragment}, [3]int{14, 35, 7})
+		assert.Equal(t, marsTime.NanoFragment(), 5)
+	})
+	t.Run("LeapSol", func(t *testing.T) {
+		marsTime := planets.MarsDate(221, 24, 28, 0, 0, 0, 0)
+		assert.Equal(t, marsTime.Month(), planets.Vrishika)
+		assert.Equal(t, marsTime.Sol(), 28)
+		assert.Equal(t, marsTime.SolOfRotation(), 669)
+		assert.Equal(t, marsTime.Week(), 96)
+		assert.Equal(t, marsTime.WeekSol(), planets.Saturni)
+	})
+	t.Run("BeforeEpoch", func(t *testing.T) {
+		marsTime := planets.MarsTime{TotalSols: -1}
+		assert.Equal(t, marsTime.Rotation(), -1)
+		assert.Equal(t, marsTime.Month(), planets.Vrishika)
+		assert.Equal(t, marsTime.SolOfRotation(), 669)
+	})
+	t.Run("MatchesFormat", func(t *testing.T) {
+		for _, marsTime := range []planets.MarsTime{
+			planets.MarsDate(201, 2, 3, 4, 5, 6, 0),
+			planets.MarsDate(207, 21, 14, 16, 14, 10, 0),
+		} {
+			vinqua, layer, fragment := marsTime.Clock()
+			assert.Equal(t,
+				fmt.Sprintf("%d %v %d %d %v %d|%d|%d", marsTime.Rotation(), marsTime.Month(), marsTime.Sol(), marsTime.Week(), marsTime.WeekSol(), vinqua, layer, fragment),
+				marsTime.Format("%R %NM %S %W %NS %V|%L|%F"),
+			)
+		}
+	})
+}
@@ -0,0 +1,502 @@
+package planets
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+)
+
+// LayoutCandidate is a layout proposed by DetectLayout
+type LayoutCandidate struct {
+	Layout string
+	// Confidence is in (0, 1]; confidences of all candidates sum to 1
+	Confidence float64
+}
+
+// maxLayoutCandidates limits number of candidates DetectLayout returns
+const maxLayoutCandidates = 10
+
+// DetectLayout guesses layouts that parse every one of samples and format
+// the parsed time back into the sample, most likely first. Numbers are
+// matched to rotation, month, week, sol, weekSol and clock values by their
+// ranges, widths, order and separators (':' and '|' between clock values,
+// '=', '-' and '/' between date values), while words are matched against
+// month and weekSol names, ordinal suffixes and AM/PM markers.
+// Samples missing a value are checked as ParseInto of MarsTime{} would
+// parse them. DetectLayout returns nil when samples do not share their
+// structure or no layout fits them.
+func DetectLayout(samples ...string) []LayoutCandidate {
+	runs := detectRuns(samples)
+	if runs == nil {
+		return nil
+	}
+
+	var leaves []detectLeaf
+	chosen := make([]detectOption, len(runs))
+	var walk func(i int, used detectRole)
+	walk = func(i int, used detectRole) {
+		if i == len(runs) {
+			leaves = append(leaves, detectLeaf{detectLayoutOf(chosen), detectPenalty(runs, chosen)})
+			return
+		}
+		for _, opt := range runs[i].options {
+			if opt.role&used != 0 {
+				continue
+			}
+			chosen[i] = opt
+			walk(i+1, used|opt.role)
+		}
+	}
+	walk(0, 0)
+	slices.SortStableFunc(leaves, func(a, b detectLeaf) int {
+		return a.penalty - b.penalty
+	})
+
+	var candidates []LayoutCandidate
+	total := 0.0
+	for _, leaf := range leaves {
+		if len(candidates) == maxLayoutCandidates {
+			break
+		}
+		if slices.ContainsFunc(candidates, func(c LayoutCandidate) bool { return c.Layout == leaf.layout }) {
+			continue
+		}
+		if !detectFits(leaf.layout, samples) {
+			continue
+		}
+		weight := 1 / float64(uint64(1)<<min(leaf.penalty, 63))
+		candidates = append(candidates, LayoutCandidate{leaf.layout, weight})
+		total += weight
+	}
+	for i := range candidates {
+		candidates[i].Confidence /= total
+	}
+	return candidates
+}
+
+// detectRole is set of values a layout token provides; each value is
+// provided by at most one token of a detected layout
+type detectRole uint16
+
+const (
+	roleRotation detectRole = 1 << iota
+	roleMonth
+	roleWeek
+	roleSol
+	roleWeekSol
+	roleVinqua
+	roleLayer
+	roleFragment
+	roleNanofragment
+	roleAMPM
+)
+
+// rank orders roles from most to least significant; 0 for roles without order
+func (r detectRole) rank() int {
+	switch r {
+	case roleRotation:
+		return 1
+	case roleMonth, roleWeek:
+		return 2
+	case roleSol, roleWeekSol:
+		return 3
+	case roleVinqua:
+		return 4
+	case roleLayer:
+		return 5
+	case roleFragment:
+		return 6
+	case roleNanofragment:
+		return 7
+	}
+	return 0
+}
+
+func isClockRank(rank int) bool {
+	return rank >= roleVinqua.rank()
+}
+
+// detectOption is one way of writing a run of samples in layout
+type detectOption struct {
+	text    string
+	isToken bool
+	// role holds all values provided by text, ranks their ranks in order
+	role    detectRole
+	ranks   []int
+	penalty int
+}
+
+func tokenOption(token string, role detectRole) detectOption {
+	return detectOption{text: token, isToken: true, role: role, ranks: []int{role.rank()}}
+}
+
+// detectRun is a run of digits, of letters or of other characters
+// at the same position of every sample
+type detectRun struct {
+	kind    byte
+	texts   []string
+	options []detectOption
+}
+
+type detectLeaf struct {
+	layout  string
+	penalty int
+}
+
+const (
+	runDigits  = 'd'
+	runLetters = 'a'
+	runOther   = '.'
+)
+
+func runKind(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return runDigits
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		return runLetters
+	}
+	return runOther
+}
+
+// splitRuns splits sample into runs; sign at the start of sample or after
+// a space stays with the digits following it
+func splitRuns(sample string) (kinds []byte, texts []string) {
+	for i := 0; i < len(sample); {
+		start := i
+		kind := runKind(sample[i])
+		if (sample[i] == '-' || sample[i] == '+') && i+1 < len(sample) && runKind(sample[i+1]) == runDigits && (i == 0 || isSpace(sample[i-1])) {
+			kind = runDigits
+			i++
+		}
+		for i++; i < len(sample) && runKind(sample[i]) == kind; i++ {
+			if kind == runOther && (sample[i] == '-' || sample[i] == '+') && i+1 < len(sample) && runKind(sample[i+1]) == runDigits && isSpace(sample[i-1]) {
+				break
+			}
+		}
+		kinds = append(kinds, kind)
+		texts = append(texts, sample[start:i])
+	}
+	return
+}
+
+// detectRuns splits samples into runs with options for each of them,
+// returning nil when samples differ in structure
+func detectRuns(samples []string) []detectRun {
+	if len(samples) == 0 {
+		return nil
+	}
+	var runs []detectRun
+	for n, sample := range samples {
+		kinds, texts := splitRuns(sample)
+		if n == 0 {
+			runs = make([]detectRun, len(kinds))
+			for i := range runs {
+				runs[i].kind = kinds[i]
+			}
+		}
+		if len(kinds) != len(runs) {
+			return nil
+		}
+		for i := range runs {
+			if kinds[i] != runs[i].kind {
+				return nil
+			}
+			runs[i].texts = append(runs[i].texts, texts[i])
+		}
+	}
+	if len(runs) == 0 {
+		return nil
+	}
+
+	for i := 0; i < len(runs); i++ {
+		run := &runs[i]
+		switch run.kind {
+		case runOther:
+			if !allEqual(run.texts) {
+				return nil
+			}
+			run.options = []detectOption{{text: run.texts[0]}}
+		case runDigits:
+			afterDot := i > 0 && strings.HasSuffix(runs[i-1].texts[0], ".")
+			if i+1 < len(runs) && isOrdinalOf(runs[i+1].texts, run.texts) {
+				if !inRange(run.texts, 1, 28) {
+					return nil
+				}
+				run.options = []detectOption{tokenOption("%oS", roleSol)}
+				runs = slices.Delete(runs, i+1, i+2)
+				continue
+			}
+			run.options = numericOptions(run.texts, afterDot)
+		case runLetters:
+			run.options = letterOptions(run.texts)
+		}
+		if len(run.options) == 0 {
+			return nil
+		}
+	}
+	return runs
+}
+
+func allEqual(texts []string) bool {
+	for _, text := range texts {
+		if text != texts[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func isOrdinalOf(suffixes []string, numbers []string) bool {
+	for i, suffix := range suffixes {
+		n, err := strconv.Atoi(numbers[i])
+		if err != nil || format.Ordinal(n) != numbers[i]+suffix {
+			return false
+		}
+	}
+	return true
+}
+
+// inRange reports whether every one of texts is unsigned number in [lo, hi]
+func inRange(texts []string, lo int, hi int) bool {
+	for _, text := range texts {
+		if text[0] == '-' || text[0] == '+' {
+			return false
+		}
+		n, err := strconv.Atoi(text)
+		if err != nil || n < lo || n > hi {
+			return false
+		}
+	}
+	return true
+}
+
+// paddedToken picks between unpadded and zero padded variant of token
+// by widths of texts
+func paddedToken(texts []string, token string) (string, bool) {
+	padded := true
+	for _, text := range texts {
+		switch len(text) {
+		case 1:
+			padded = false
+		case 2:
+		default:
+			return "", false
+		}
+	}
+	if padded {
+		return "%0" + token[1:], true
+	}
+	return token, true
+}
+
+func numericOptions(texts []string, afterDot bool) []detectOption {
+	var options []detectOption
+	add := func(token string, role detectRole, lo int, hi int) {
+		if !inRange(texts, lo, hi) {
+			return
+		}
+		if token, ok := paddedToken(texts, token); ok {
+			options = append(options, tokenOption(token, role))
+		}
+	}
+
+	signed := false
+	for _, text := range texts {
+		if text[0] == '+' {
+			signed = true
+		}
+	}
+	if signed {
+		options = append(options, tokenOption("%+R", roleRotation))
+	} else {
+		options = append(options, tokenOption("%R", roleRotation))
+	}
+	add("%M", roleMonth, 1, 24)
+	add("%W", roleWeek, 1, 96)
+	add("%S", roleSol, 1, 28)
+	if inRange(texts, 1, 7) && !slices.ContainsFunc(texts, func(s string) bool { return len(s) != 1 }) {
+		options = append(options, tokenOption("%WS", roleWeekSol))
+	}
+	add("%V", roleVinqua, 0, 23)
+	add("%V12", roleVinqua, 1, 12)
+	add("%L", roleLayer, 0, 59)
+	add("%F", roleFragment, 0, 59)
+	if inRange(texts, 0, 999_999_999) {
+		if !slices.ContainsFunc(texts, func(s string) bool { return len(s) != 9 }) {
+			options = append(options, tokenOption("%f0", roleNanofragment))
+		} else if afterDot {
+			options = append(options, tokenOption("%f", roleNanofragment))
+		}
+	}
+	return append(options, compactOptions(texts)...)
+}
+
+// compactFields are values compact layouts write zero padded one after another
+var compactFields = []struct {
+	token  string
+	role   detectRole
+	lo, hi int
+}{
+	{"%0M", roleMonth, 1, 24},
+	{"%0S", roleSol, 1, 28},
+	{"%0V", roleVinqua, 0, 23},
+	{"%0L", roleLayer, 0, 59},
+	{"%0F", roleFragment, 0, 59},
+}
+
+// compactOptions splits digits into consecutive values of compactFields,
+// optionally preceded by rotation, as in "%R%0M%0S" or "%0V%0L%0F"
+func compactOptions(texts []string) []detectOption {
+	var options []detectOption
+	for first := range compactFields {
+		for last := first + 1; last < len(compactFields); last++ {
+			width := 2 * (last - first + 1)
+			withRotation := first == 0
+			for _, text := range texts {
+				if text[0] == '-' || text[0] == '+' || len(text) != width {
+					withRotation = withRotation && len(text) > width
+				}
+			}
+			allWidth := !slices.ContainsFunc(texts, func(s string) bool { return len(s) != width })
+			if !withRotation && !allWidth {
+				continue
+			}
+
+			opt := detectOption{isToken: true}
+			if withRotation {
+				opt.text, opt.role, opt.ranks = "%R", roleRotation, []int{roleRotation.rank()}
+			}
+			fits := true
+			for i, field := range compactFields[first : last+1] {
+				values := make([]string, len(texts))
+				for n, text := range texts {
+					end := len(text) - width + 2*(i+1)
+					values[n] = text[end-2 : end]
+				}
+				fits = fits && inRange(values, field.lo, field.hi)
+				opt.text += field.token
+				opt.role |= field.role
+				opt.ranks = append(opt.ranks, field.role.rank())
+			}
+			if fits {
+				options = append(options, opt)
+			}
+		}
+	}
+	return options
+}
+
+func letterOptions(texts []string) []detectOption {
+	var t MarsTime
+	var options []detectOption
+	tables := []struct {
+		names []string
+		token string
+		role  detectRole
+	}{
+		{t.LongMonthNames(), "%NM", roleMonth},
+		{t.ShortMonthNames(), "%nM", roleMonth},
+		{t.LongWeekSolNames(), "%NS", roleWeekSol},
+		{t.ShortWeekSolNames(), "%nS", roleWeekSol},
+		{[]string{"A", "P"}, "%Vu", roleAMPM},
+		{[]string{"a", "p"}, "%Vl", roleAMPM},
+	}
+	for _, table := range tables {
+		matches := true
+		for _, text := range texts {
+			matches = matches && slices.Contains(table.names, text)
+		}
+		if matches {
+			options = append(options, tokenOption(table.token, table.role))
+		}
+	}
+	if allEqual(texts) {
+		// words are more likely names than literals when they are both
+		options = append(options, detectOption{text: texts[0], penalty: min(len(options), 1) * 3})
+	}
+	return options
+}
+
+// detectLayoutOf joins chosen options into layout
+func detectLayoutOf(chosen []detectOption) string {
+	var sb strings.Builder
+	for i, opt := range chosen {
+		if opt.isToken {
+			sb.WriteString(opt.text)
+			continue
+		}
+		c := opt.text[0]
+		if i > 0 && chosen[i-1].isToken && (runKind(c) != runOther || c == '_') {
+			sb.WriteString("%'")
+		}
+		sb.WriteString(strings.ReplaceAll(opt.text, "%", "%%"))
+	}
+	return sb.String()
+}
+
+// detectPenalty scores how unlikely chosen options are
+func detectPenalty(runs []detectRun, chosen []detectOption) int {
+	penalty := 0
+	minRank := 0
+	var numbers []int
+	prev := -1
+	for i, opt := range chosen {
+		penalty += opt.penalty
+		for _, rank := range opt.ranks {
+			if rank != 0 && (minRank == 0 || rank < minRank) {
+				minRank = rank
+			}
+		}
+		if runs[i].kind != runDigits {
+			continue
+		}
+		// separators suggesting another kind of value
+		if prev >= 0 && prev == i-2 {
+			sep := strings.TrimSpace(chosen[i-1].text)
+			before := chosen[prev].ranks[len(chosen[prev].ranks)-1]
+			after := opt.ranks[0]
+			sameKind := isClockRank(before) == isClockRank(after)
+			if (sep == ":" || sep == "|") && !(sameKind && isClockRank(after)) || (sep == "=" || sep == "-" || sep == "/") && !(sameKind && !isClockRank(after)) {
+				penalty += 4
+			}
+		}
+		prev = i
+		numbers = append(numbers, opt.ranks...)
+	}
+	for i, rank := range numbers {
+		// numbers out of order
+		for _, earlier := range numbers[:i] {
+			if earlier > rank {
+				penalty += 1
+			}
+		}
+		// numbers skipping values between them
+		if i > 0 && rank > numbers[i-1]+1 {
+			penalty += rank - numbers[i-1] - 1
+		}
+	}
+	// most significant values missing
+	if minRank > 1 {
+		penalty += 1
+	}
+	return penalty
+}
+
+// detectFits reports whether layout parses each of samples back into itself
+func detectFits(layout string, samples []string) bool {
+	l, err := CompileLayout(layout)
+	if err != nil {
+		return false
+	}
+	opts := ParseOptions{Reference: &MarsTime{}}
+	for _, sample := range samples {
+		mt, err := l.ParseWithOptions(sample, opts)
+		if err != nil || l.Format(mt) != sample {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package planets_test
+
+import (
+	"math"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestDetectLayout(t *testing.T) {
+	t.Run("BestCandidate", func(t *testing.T) {
+		tests := []struct {
+			samples  []string
+			expected string
+		}{
+			{[]string{"221=08=26T14|35|00"}, "%R=%0M=%0S%'T%0V|%0L|%0F"},
+			{[]string{"Jovis, 221 Mina 26th @ 14|35|00"}, "%NS, %R %NM %oS @ %0V|%0L|%0F"},
+			{[]string{"221=8=26", "221=12=03"}, "%R=%M=%0S"},
+			{[]string{"-3=16=17T10|13|57"}, "%R=%0M=%0S%'T%0V|%0L|%0F"},
+			{[]string{"+221 Mina 26"}, "%+R %NM %0S"},
+			{[]string{"2210826T143500"}, "%R%0M%0S%'T%0V%0L%0F"},
+			{[]string{"221=08=26 02:35 P"}, "%R=%0M=%0S %0V12:%0L %Vu"},
+			{[]string{"26/08/221"}, "%0S/%0M/%R"},
+			{[]string{"rotation 221 week 30 Jovis"}, "rotation %R week %0W %NS"},
+			{[]string{"14|35|00"}, "%0V|%0L|%0F"},
+			{[]string{"221=08=26 14|35|00.5"}, "%R=%0M=%0S %0V|%0L|%0F.%f"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.samples[0], func(t *testing.T) {
+				candidates := planets.DetectLayout(tc.samples...)
+				assert.NotEqual(t, len(candidates), 0)
+				assert.Equal(t, candidates[0].Layout, tc.expected)
+			})
+		}
+	})
+	t.Run("Candidates", func(t *testing.T) {
+		samples := []string{"221=08=26T14|35|00", "207=21=14T16|14|10"}
+		candidates := planets.DetectLayout(samples...)
+		sum := 0.0
+		for i, candidate := range candidates {
+			sum += candidate.Confidence
+			if i > 0 {
+				assert.Equal(t, candidate.Confidence <= candidates[i-1].Confidence, true)
+			}
+			l := planets.MustCompileLayout(candidate.Layout)
+			for _, sample := range samples {
+				marsTime, err := l.ParseWithOptions(sample, planets.ParseOptions{Reference: &planets.MarsTime{}})
+				assert.Equal(t, err, nil)
+				assert.Equal(t, l.Format(marsTime), sample)
+			}
+		}
+		assert.Equal(t, math.Abs(sum-1) < 1e-9, true)
+	})
+	t.Run("NoCandidates", func(t *testing.T) {
+		for _, samples := range [][]string{
+			{},
+			{""},
+			{"no numbers here", "none at all here"},
+			{"221=08=26", "221/08/26"},
+			{"221=08=26", "221=08"},
+			{"221=99=99"},
+		} {
+			assert.Equal(t, len(planets.DetectLayout(samples...)), 0)
+		}
+	})
+}
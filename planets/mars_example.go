@@ -0,0 +1,162 @@
+package planets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrUnrecognizedNumber = errors.New("number does not match any value of reference time")
+	ErrAmbiguousExample   = errors.New("name or marker is part of longer word")
+)
+
+// ExampleError describes fragment of example that ExampleToLayoutE
+// could not turn into layout
+type ExampleError struct {
+	Example  string
+	Offset   int // byte offset of Fragment within Example
+	Column   int // offset of Fragment within Example in characters (runes)
+	Fragment string
+	Err      error
+}
+
+func (e *ExampleError) Error() string {
+	return fmt.Sprintf("example %q at [%d] fragment %q: %v", e.Example, e.Offset, e.Fragment, e.Err)
+}
+
+func (e *ExampleError) Unwrap() error {
+	return e.Err
+}
+
+// ExampleSubstitution describes fragment of example that was replaced
+// by Token when converting example into layout
+type ExampleSubstitution struct {
+	Offset      int
+	Example     string
+	Token       string
+	Description string
+}
+
+// exampleReplacement is fragment of reference time 203=04=05T00|01|02.3
+// (Jovis, 203 Makara 5th, week 13) and layout it stands for
+type exampleReplacement struct {
+	example     string
+	layout      string
+	description string
+}
+
+// ExampleToLayout converts example showing reference time into layout,
+// copying anything it does not recognize into layout as literal
+func (t MarsTime) ExampleToLayout(example string) (layout string) {
+	layout, _, _ = exampleToLayout(example, false)
+	return layout
+}
+
+// ExampleToLayoutE is ExampleToLayout that returns *ExampleError for digits
+// not matching any value of reference time and for names or AM/PM markers
+// that are part of longer word, instead of copying them into layout;
+// subs explains each replacement made
+func (t MarsTime) ExampleToLayoutE(example string) (layout string, subs []ExampleSubstitution, err error) {
+	return exampleToLayout(example, true)
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// letterBefore reports whether character of s ending at i is a letter
+func letterBefore(s string, i int) bool {
+	r, _ := utf8.DecodeLastRuneInString(s[:i])
+	return i > 0 && unicode.IsLetter(r)
+}
+
+// letterAt reports whether character of s starting at i is a letter
+func letterAt(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return i < len(s) && unicode.IsLetter(r)
+}
+
+func exampleError(example string, start int, end int, err error) *ExampleError {
+	return &ExampleError{
+		Example:  example,
+		Offset:   start,
+		Column:   utf8.RuneCountInString(example[:start]),
+		Fragment: example[start:end],
+		Err:      err,
+	}
+}
+
+func exampleToLayout(example string, strict bool) (layout string, subs []ExampleSubstitution, err error) {
+	var sb strings.Builder
+	isWeekAdded := false
+
+	for i := 0; i < len(example); {
+		var match *exampleReplacement
+		for n := range exampleReplacements {
+			if strings.HasPrefix(example[i:], exampleReplacements[n].example) {
+				match = &exampleReplacements[n]
+				break
+			}
+		}
+		if strict && match != nil && isLetter(match.example[0]) {
+			end := i + len(match.example)
+			if letterBefore(example, i) || isLetter(match.example[len(match.example)-1]) && letterAt(example, end) {
+				start, stop := i, end
+				for letterBefore(example, start) {
+					_, size := utf8.DecodeLastRuneInString(example[:start])
+					start -= size
+				}
+				for letterAt(example, stop) {
+					_, size := utf8.DecodeRuneInString(example[stop:])
+					stop += size
+				}
+				return "", nil, exampleError(example, start, stop, ErrAmbiguousExample)
+			}
+		}
+		if match != nil {
+			sb.WriteString(match.layout + "%'")
+			subs = append(subs, ExampleSubstitution{i, match.example, match.layout, match.description})
+			if match.layout == "%W" || match.layout == "%0W" {
+				isWeekAdded = true
+			}
+			i += len(match.example)
+			continue
+		}
+
+		if strict && isDigit(example[i]) {
+			// report whole number, as part of it may have matched already
+			start, end := i, i+1
+			for start > 0 && isDigit(example[start-1]) {
+				start--
+			}
+			for end < len(example) && isDigit(example[end]) {
+				end++
+			}
+			return "", nil, exampleError(example, start, end, ErrUnrecognizedNumber)
+		}
+		if example[i] == '%' {
+			sb.WriteString("%%")
+		} else {
+			sb.WriteByte(example[i])
+		}
+		i++
+	}
+
+	layout = sb.String()
+	if isWeekAdded {
+		layout = strings.ReplaceAll(layout, "%S", "%WS")
+		for n := range subs {
+			if subs[n].Token == "%S" {
+				subs[n].Token, subs[n].Description = "%WS", "Weekday number (1–7)"
+			}
+		}
+	}
+	return layout, subs, nil
+}
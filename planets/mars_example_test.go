@@ -0,0 +1,73 @@
+package planets_test
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestExampleToLayoutE(t *testing.T) {
+	t.Run("MatchesExampleToLayout", func(t *testing.T) {
+		for _, example := range []string{
+			"203=04=05T00|01|02",
+			"rot 203 m4 sol 5 started 0 vinquas 1 layers 2 fragments ago",
+			"203 Makara 5th",
+			"203=W13=5",
+			"rotation%203 week !13 Jovis",
+			"203=04=05T00|01|02.300000000",
+			"203=04=05A00|01|02",
+			"203=04=05 a.m. 12|01|02",
+			"2030405T000102",
+		} {
+			layout, _, err := planets.MarsTime{}.ExampleToLayoutE(example)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, layout, planets.MarsTime{}.ExampleToLayout(example))
+		}
+	})
+	t.Run("Substitutions", func(t *testing.T) {
+		layout, subs, err := planets.MarsTime{}.ExampleToLayoutE("Jovis, 203 Makara 5th")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, layout, "%NS%', %R%' %NM%' %oS%'")
+		assert.Equal(t, subs, []planets.ExampleSubstitution{
+			{Offset: 0, Example: "Jovis", Token: "%NS", Description: "Full Sol (weekday) name"},
+			{Offset: 7, Example: "203", Token: "%R", Description: "Rotation (year)"},
+			{Offset: 11, Example: "Makara", Token: "%NM", Description: "Full Month name"},
+			{Offset: 18, Example: "5th", Token: "%oS", Description: "Ordinal Sol (e.g. 14th)"},
+		})
+	})
+	t.Run("WeekSol", func(t *testing.T) {
+		_, subs, err := planets.MarsTime{}.ExampleToLayoutE("203=W13=5")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, subs[2].Token, "%WS")
+	})
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			example  string
+			offset   int
+			fragment string
+			err      error
+		}{
+			{"203=04=07", 7, "07", planets.ErrUnrecognizedNumber},
+			{"203=04=05 #9", 11, "9", planets.ErrUnrecognizedNumber},
+			{"203 Makaras", 4, "Makaras", planets.ErrAmbiguousExample},
+			{"Jovisday 203", 0, "Jovisday", planets.ErrAmbiguousExample},
+			{"203 Makeup", 4, "Makeup", planets.ErrAmbiguousExample},
+			{"203 DA.", 4, "DA.", planets.ErrAmbiguousExample},
+		}
+		for _, tc := range tests {
+			t.Run(tc.example, func(t *testing.T) {
+				layout, subs, err := planets.MarsTime{}.ExampleToLayoutE(tc.example)
+				assert.Equal(t, layout, "")
+				assert.Equal(t, len(subs), 0)
+				var exampleErr *planets.ExampleError
+				assert.Equal(t, errors.As(err, &exampleErr), true)
+				assert.Equal(t, exampleErr.Offset, tc.offset)
+				assert.Equal(t, exampleErr.Fragment, tc.fragment)
+				assert.Equal(t, errors.Is(err, tc.err), true)
+			})
+		}
+	})
+}
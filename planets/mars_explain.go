@@ -0,0 +1,44 @@
+package planets
+
+// exampleTime is the reference time ExampleToLayout examples are written for
+var exampleTime = MarsDate(203, 4, 5, 0, 1, 2, 300000000)
+
+// LayoutToExample formats reference time 203=04=05T00|01|02.3 by layout,
+// which is the reverse of ExampleToLayout for most layouts
+func (t MarsTime) LayoutToExample(layout string) (string, error) {
+	return exampleTime.FormatE(layout)
+}
+
+// LayoutToken describes a token or a literal text of layout
+type LayoutToken struct {
+	Offset int
+	// Token is empty for literal text
+	Token string
+	// Example is the token formatted for 203=04=05T00|01|02.3, or the literal text
+	Example     string
+	Description string
+}
+
+// Explain describes each token and literal text of layout in order,
+// returning *FormatError if layout contains an unknown token
+func (t MarsTime) Explain(layout string) ([]LayoutToken, error) {
+	var res []LayoutToken
+	f := exampleTime.formatFields(usesDate | usesClock)
+	for i := 0; i < len(layout); {
+		if layout[i] != '%' {
+			start := i
+			for i < len(layout) && layout[i] != '%' {
+				i++
+			}
+			res = append(res, LayoutToken{Offset: start, Example: layout[start:i], Description: "Literal text"})
+			continue
+		}
+		def, err := scanToken(layout, i, builtinToken)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, LayoutToken{i, def.token, string(def.format(f, nil)), def.description})
+		i += len(def.token)
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+package planets_test
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestLayoutToExample(t *testing.T) {
+	tests := []struct {
+		layout   string
+		expected string
+	}{
+		{"%R=%0M=%0S%'T%0V|%0L|%0F", "203=04=05T00|01|02"},
+		{"%R=%0M=%0S%'T%0V|%0L|%0F.%f0", "203=04=05T00|01|02.300000000"},
+		{"%NS, %R %NM %oS", "Jovis, 203 Makara 5th"},
+		{"%R=%0M=%0D%Vu%0V11|%0L|%0F", "203=04=05A00|01|02"},
+		{"rotation%%%R week %W %nS", "rotation%203 week 13 Jov"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.layout, func(t *testing.T) {
+			example, err := planets.MarsTime{}.LayoutToExample(tc.layout)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, example, tc.expected)
+
+			marsTime := planets.MarsDate(207, 21, 14, 16, 14, 10, 712563515)
+			assert.Equal(t, marsTime.FormatExample(example), marsTime.Format(tc.layout))
+		})
+	}
+	t.Run("Error", func(t *testing.T) {
+		_, err := planets.MarsTime{}.LayoutToExample("%R %Q")
+		var formatErr *planets.FormatError
+		assert.Equal(t, errors.As(err, &formatErr), true)
+	})
+}
+
+func TestExplain(t *testing.T) {
+	tokens, err := planets.MarsTime{}.Explain("%R=%0M=%0D%Vu%0V11|%0L|%0F%%%'x")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, tokens, []planets.LayoutToken{
+		{Offset: 0, Token: "%R", Example: "203", Description: "Rotation (year)"},
+		{Offset: 2, Example: "=", Description: "Literal text"},
+		{Offset: 3, Token: "%0M", Example: "04", Description: "Zero Padded Month"},
+		{Offset: 6, Example: "=", Description: "Literal text"},
+		{Offset: 7, Token: "%0D", Example: "05", Description: "Same as `%0S`"},
+		{Offset: 10, Token: "%Vu", Example: "A", Description: "`A` if Vinqua < 12 else `P`"},
+		{Offset: 13, Token: "%0V11", Example: "00", Description: "Zero Padded Vinqua % 12"},
+		{Offset: 18, Example: "|", Description: "Literal text"},
+		{Offset: 19, Token: "%0L", Example: "01", Description: "Zero Padded Layer"},
+		{Offset: 22, Example: "|", Description: "Literal text"},
+		{Offset: 23, Token: "%0F", Example: "02", Description: "Zero Padded Fragment"},
+		{Offset: 26, Token: "%%", Example: "%", Description: "Literal `%` character"},
+		{Offset: 28, Token: "%'", Example: "", Description: "Used to split tokens from text"},
+		{Offset: 30, Example: "x", Description: "Literal text"},
+	})
+
+	_, err = planets.MarsTime{}.Explain("%R %Q")
+	var formatErr *planets.FormatError
+	assert.Equal(t, errors.As(err, &formatErr), true)
+	assert.Equal(t, formatErr.Offset, 3)
+}
@@ -0,0 +1,112 @@
+package planets
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTokenNotParsable is returned when parsing a layout containing
+// a custom token registered without parse function
+var ErrTokenNotParsable = errors.New("token has no parse function")
+
+// Formatter formats and parses layouts that can contain custom tokens
+// besides the built-in ones; the zero value knows only built-in tokens.
+// RegisterToken must not be called concurrently with other methods.
+type Formatter struct {
+	tokens map[string]*tokenDef
+}
+
+// RegisterToken adds custom token to f. Token is '%' followed by one to four
+// letters, digits or '_' and can not be a built-in token. Format gives text
+// of token for MarsTime. Parse, which can be nil, returns how many bytes at
+// the start of input belong to token; parsed token does not change the
+// resulting MarsTime. Like built-in tokens, custom token followed by text
+// that could continue it has to be separated by "%'".
+func (f *Formatter) RegisterToken(token string, format func(t MarsTime) string, parse func(input string) (consumed int, err error)) error {
+	if len(token) < 2 || len(token) > 5 || token[0] != '%' {
+		return fmt.Errorf("custom token %q must be '%%' followed by 1 to 4 characters", token)
+	}
+	for _, c := range []byte(token[1:]) {
+		if !isLetter(c) && !isDigit(c) && c != '_' {
+			return fmt.Errorf("custom token %q can contain only letters, digits and '_' after '%%'", token)
+		}
+	}
+	if tokenDefs[token] != nil {
+		return fmt.Errorf("custom token %q conflicts with built-in token", token)
+	}
+	if format == nil {
+		return fmt.Errorf("custom token %q needs format function", token)
+	}
+
+	def := &tokenDef{
+		token:       token,
+		description: "Custom token",
+		format: func(fields formatFields, b []byte) []byte {
+			return append(b, format(fields.t)...)
+		},
+		parse: func(st *parseState, def *tokenDef, input string) (int, int, error) {
+			if parse == nil {
+				return 0, 0, ErrTokenNotParsable
+			}
+			consumed, err := parse(input)
+			if err == nil && (consumed < 0 || consumed > len(input)) {
+				err = fmt.Errorf("parse function of custom token consumed %d bytes of %q", consumed, input)
+			}
+			return 0, consumed, err
+		},
+	}
+	if f.tokens == nil {
+		f.tokens = map[string]*tokenDef{}
+	}
+	f.tokens[token] = def
+	return nil
+}
+
+func (f *Formatter) lookup(token string) *tokenDef {
+	if def := f.tokens[token]; def != nil {
+		return def
+	}
+	return tokenDefs[token]
+}
+
+// CompileLayout is CompileLayout that knows custom tokens of f
+func (f *Formatter) CompileLayout(layout string) (*Layout, error) {
+	return compileLayout(layout, f.lookup)
+}
+
+// Format is MarsTime.Format that knows custom tokens of f
+func (f *Formatter) Format(t MarsTime, layout string) string {
+	res, err := f.FormatE(t, layout)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return res
+}
+
+// FormatE is MarsTime.FormatE that knows custom tokens of f
+func (f *Formatter) FormatE(t MarsTime, layout string) (string, error) {
+	b, err := f.AppendFormat(make([]byte, 0, len(layout)+16), t, layout)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// AppendFormat is MarsTime.AppendFormat that knows custom tokens of f
+func (f *Formatter) AppendFormat(b []byte, t MarsTime, layout string) ([]byte, error) {
+	return t.appendFormat(b, layout, f.lookup, DefaultLocale)
+}
+
+// Parse is MarsTime.Parse that knows custom tokens of f
+func (f *Formatter) Parse(layout string, input string) (MarsTime, error) {
+	return f.ParseWithOptions(layout, input, ParseOptions{})
+}
+
+// ParseWithOptions is MarsTime.ParseWithOptions that knows custom tokens of f
+func (f *Formatter) ParseWithOptions(layout string, input string, opts ParseOptions) (MarsTime, error) {
+	l, err := f.CompileLayout(layout)
+	if err != nil {
+		return MarsTime{}, layoutParseError(layout, input, err)
+	}
+	return l.ParseWithOptions(input, opts)
+}
@@ -0,0 +1,80 @@
+package planets_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func newTestFormatter(t *testing.T) *planets.Formatter {
+	var f planets.Formatter
+	shift := func(mt planets.MarsTime) string {
+		vinqua, _, _ := mt.Clock()
+		return format.Iota(vinqua/8 + 1)
+	}
+	parseShift := func(input string) (int, error) {
+		if len(input) == 0 || input[0] < '1' || input[0] > '3' {
+			return 0, fmt.Errorf("expected shift 1 thru 3, got %q", input)
+		}
+		return 1, nil
+	}
+	assert.Equal(t, f.RegisterToken("%Q", shift, parseShift), nil)
+	assert.Equal(t, f.RegisterToken("%X", func(planets.MarsTime) string { return "GALE" }, nil), nil)
+	return &f
+}
+
+func TestFormatter(t *testing.T) {
+	marsTime := planets.MarsDate(221, 8, 26, 14, 35, 0, 0)
+	f := newTestFormatter(t)
+	t.Run("Format", func(t *testing.T) {
+		assert.Equal(t, f.Format(marsTime, "%X %R=%0M=%0S shift %Q"), "GALE 221=08=26 shift 2")
+		assert.Equal(t, f.Format(marsTime, "%Q%'A"), "2A")
+		l, err := f.CompileLayout("%X@%0V|%0L")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, l.Format(marsTime), "GALE@14|35")
+	})
+	t.Run("Parse", func(t *testing.T) {
+		parsed, err := f.Parse("%R=%0M=%0S%'T%0V|%0L shift %Q", "221=08=26T14|35 shift 2")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, parsed, marsTime)
+
+		_, err = f.Parse("%R=%0M=%0S shift %Q", "221=08=26 shift 7")
+		var parseErr *planets.ParseError
+		assert.Equal(t, errors.As(err, &parseErr), true)
+		assert.Equal(t, parseErr.Token, "%Q")
+
+		_, err = f.Parse("%X %R=%0M=%0S", "GALE 221=08=26")
+		assert.Equal(t, errors.Is(err, planets.ErrTokenNotParsable), true)
+	})
+	t.Run("UnknownTokens", func(t *testing.T) {
+		_, err := f.FormatE(marsTime, "%Q %Z1")
+		var formatErr *planets.FormatError
+		assert.Equal(t, errors.As(err, &formatErr), true)
+		assert.Equal(t, formatErr.Offset, 3)
+		assert.Equal(t, formatErr.Fragment, "%Z1")
+		assert.Equal(t, strings.HasPrefix(f.Format(marsTime, "%Z"), "error: "), true)
+	})
+	t.Run("NotGlobal", func(t *testing.T) {
+		_, err := marsTime.FormatE("%Q")
+		assert.NotEqual(t, err, nil)
+		_, err = planets.CompileLayout("%Q")
+		assert.NotEqual(t, err, nil)
+		var other planets.Formatter
+		_, err = other.FormatE(marsTime, "%Q")
+		assert.NotEqual(t, err, nil)
+		assert.Equal(t, other.Format(marsTime, "%R"), "221")
+	})
+	t.Run("RegisterErrors", func(t *testing.T) {
+		format := func(planets.MarsTime) string { return "" }
+		for _, token := range []string{"", "%", "Q", "%Q=", "%QQQQQ", "%R", "%0M", "%%", "%'"} {
+			assert.NotEqual(t, f.RegisterToken(token, format, nil), nil)
+		}
+		assert.NotEqual(t, f.RegisterToken("%Y", nil, nil), nil)
+	})
+}
@@ -0,0 +1,186 @@
+package planets
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Layout is a layout tokenized once by CompileLayout, so that it can be
+// used for formatting and parsing many times without scanning it again
+type Layout struct {
+	layout string
+	items  []layoutItem
+	uses   fieldUse
+}
+
+// layoutItem is either a token or, when token is empty, a literal text
+type layoutItem struct {
+	token   string
+	def     *tokenDef
+	literal string
+	offset  int // byte offset within layout
+	reserve int // digits of zero padded tokens directly following token
+}
+
+// CompileLayout tokenizes layout, returning *FormatError if it contains
+// an unknown token
+func CompileLayout(layout string) (*Layout, error) {
+	return compileLayout(layout, builtinToken)
+}
+
+func compileLayout(layout string, lookup tokenLookup) (*Layout, error) {
+	l := &Layout{layout: layout}
+	for i := 0; i < len(layout); {
+		if layout[i] != '%' {
+			start := i
+			for i < len(layout) && layout[i] != '%' {
+				i++
+			}
+			l.items = append(l.items, layoutItem{literal: layout[start:i], offset: start})
+			continue
+		}
+		def, err := scanToken(layout, i, lookup)
+		if err != nil {
+			return nil, err
+		}
+		switch def.token {
+		case "%'":
+		case "%%":
+			l.items = append(l.items, layoutItem{literal: "%", offset: i})
+		default:
+			l.items = append(l.items, layoutItem{token: def.token, def: def, offset: i})
+			l.uses |= def.field.uses()
+		}
+		i += len(def.token)
+	}
+
+	reserve := 0
+	for k := len(l.items) - 1; k >= 0; k-- {
+		item := &l.items[k]
+		if item.def != nil && item.def.variableWidth {
+			item.reserve = reserve
+		}
+		if item.def != nil && item.def.width > 0 && item.def.pad == '0' && !item.def.variableWidth {
+			reserve += item.def.width
+		} else {
+			reserve = 0
+		}
+	}
+	return l, nil
+}
+
+// MustCompileLayout is CompileLayout that panics on error,
+// meant for initializing package level variables
+func MustCompileLayout(layout string) *Layout {
+	l, err := CompileLayout(layout)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// String returns the layout l was compiled from
+func (l *Layout) String() string {
+	return l.layout
+}
+
+// Format returns t formatted by l
+func (l *Layout) Format(t MarsTime) string {
+	return string(l.AppendFormat(make([]byte, 0, len(l.layout)+16), t))
+}
+
+// AppendFormat is Format that appends the result to b
+func (l *Layout) AppendFormat(b []byte, t MarsTime) []byte {
+	return l.AppendFormatLocale(b, t, DefaultLocale)
+}
+
+// AppendFormatLocale is FormatLocale that appends the result to b
+func (l *Layout) AppendFormatLocale(b []byte, t MarsTime, locale *Locale) []byte {
+	f := t.formatFields(l.uses)
+	f.locale = locale
+	for _, item := range l.items {
+		if item.token == "" {
+			b = append(b, item.literal...)
+		} else {
+			b = item.def.format(f, b)
+		}
+	}
+	return b
+}
+
+// Parse parses input formatted by l; every error is *ParseError
+func (l *Layout) Parse(input string) (MarsTime, error) {
+	return l.ParseWithOptions(input, ParseOptions{})
+}
+
+// ParseWithOptions is Parse that matches input according to opts
+func (l *Layout) ParseWithOptions(input string, opts ParseOptions) (MarsTime, error) {
+	st := parseState{opts: opts}
+	j := 0
+	if opts.FlexibleSpace {
+		j = skipSpaces(input, j)
+	}
+	for _, item := range l.items {
+		if item.token == "" {
+			for k := 0; k < len(item.literal); {
+				if opts.FlexibleSpace && skipSpaces(item.literal, k) > k {
+					k = skipSpaces(item.literal, k)
+					j = skipSpaces(input, j)
+					continue
+				}
+				if j >= len(input) {
+					return MarsTime{}, l.parseError(input, item.offset+k, j, "", fmt.Errorf("%w: expected %q, got end of input", ErrLiteralMismatch, item.literal[k:]))
+				}
+				// compare whole characters, so that errors show them
+				expected, size := utf8.DecodeRuneInString(item.literal[k:])
+				got, gotSize := utf8.DecodeRuneInString(input[j:])
+				if item.literal[k:k+size] != input[j:j+gotSize] {
+					return MarsTime{}, l.parseError(input, item.offset+k, j, "", fmt.Errorf("%w: expected %q, got %q", ErrLiteralMismatch, expected, got))
+				}
+				k += size
+				j += gotSize
+			}
+			continue
+		}
+		tokenInput := input[j:]
+		if item.reserve > 0 {
+			var err error
+			if tokenInput, err = reserveDigits(tokenInput, item.reserve); err != nil {
+				return MarsTime{}, l.parseError(input, item.offset, j, item.token, err)
+			}
+		}
+		st.at = tokenPosition{item.offset, j, item.token}
+		consumed, err := st.parseToken(item.def, tokenInput)
+		if err != nil {
+			return MarsTime{}, l.parseError(input, item.offset, j, item.token, err)
+		}
+		j += consumed
+	}
+	if opts.FlexibleSpace {
+		j = skipSpaces(input, j)
+	}
+	if j != len(input) && !opts.AllowTrailing {
+		return MarsTime{}, l.parseError(input, len(l.layout), j, "", fmt.Errorf("%w: %q", ErrTrailingInput, input[j:]))
+	}
+	mt, at, err := st.marsTime()
+	if err != nil {
+		if at.token == "" {
+			at = tokenPosition{len(l.layout), len(input), ""}
+		}
+		return MarsTime{}, l.parseError(input, at.layoutOffset, at.inputOffset, at.token, err)
+	}
+	return mt, nil
+}
+
+func (l *Layout) parseError(input string, layoutOffset int, inputOffset int, token string, err error) *ParseError {
+	return &ParseError{
+		Layout:       l.layout,
+		Input:        input,
+		LayoutOffset: layoutOffset,
+		InputOffset:  inputOffset,
+		LayoutColumn: utf8.RuneCountInString(l.layout[:layoutOffset]),
+		InputColumn:  utf8.RuneCountInString(input[:inputOffset]),
+		Token:        token,
+		Err:          err,
+	}
+}
@@ -0,0 +1,153 @@
+package planets_test
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+var layoutTestCases = []string{
+	planets.MarsISO,
+	"rot %R m%M sol %S started %V vinquas %L layers %F fragments ago",
+	"%R %NM %oS",
+	"%R=W%0W=%WS",
+	"rotation%%%R week %W %NS",
+	"%R=%0M=%0D%Vu%0V11|%0L|%0F",
+	"%R=%0M=%0D %Vl.m. %V12|%0L|%0F.%f",
+	"%R %nM %_S %nS %f0",
+	"%%%'%%%R%%",
+}
+
+func TestCompileLayout(t *testing.T) {
+	marsTime := planets.MarsDate(207, 21, 14, 16, 14, 10, 712563515)
+	t.Run("FormatMatchesMarsTime", func(t *testing.T) {
+		for _, layout := range layoutTestCases {
+			l, err := planets.CompileLayout(layout)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, l.String(), layout)
+			assert.Equal(t, l.Format(marsTime), marsTime.Format(layout))
+			assert.Equal(t, string(l.AppendFormat([]byte("x"), marsTime)), "x"+marsTime.Format(layout))
+		}
+	})
+	t.Run("ParseMatchesMarsTime", func(t *testing.T) {
+		for _, layout := range layoutTestCases {
+			l := planets.MustCompileLayout(layout)
+			input := marsTime.Format(layout)
+			parsed, err := l.Parse(input)
+			expected, expectedErr := planets.MarsTime{}.Parse(layout, input)
+			assert.Equal(t, err, expectedErr)
+			assert.Equal(t, parsed, expected)
+			if err == nil {
+				assert.Equal(t, l.Format(parsed), input)
+			}
+		}
+	})
+	t.Run("Reusable", func(t *testing.T) {
+		l := planets.MustCompileLayout(planets.MarsISO)
+		for _, input := range []string{"201=02=03T04|05|06", "207=21=14T16|14|10", "-3=16=17T10|13|57"} {
+			parsed, err := l.Parse(input)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, l.Format(parsed), input)
+		}
+	})
+	t.Run("Errors", func(t *testing.T) {
+		for _, layout := range []string{"%0 %1", "the %0A mistake", "%R %ERR", "%"} {
+			l, err := planets.CompileLayout(layout)
+			assert.Equal(t, l, (*planets.Layout)(nil))
+			var formatErr *planets.FormatError
+			assert.Equal(t, errors.As(err, &formatErr), true)
+			_, marsTimeErr := planets.MarsTime{}.FormatE(layout)
+			assert.Equal(t, err, marsTimeErr)
+		}
+	})
+	t.Run("MustCompileLayoutPanics", func(t *testing.T) {
+		defer func() {
+			assert.NotEqual(t, recover(), nil)
+		}()
+		planets.MustCompileLayout("%Q")
+	})
+	t.Run("DoesNotAllocate", func(t *testing.T) {
+		l := planets.MustCompileLayout("%NS, %R %NM %oD @ %0V|%0L|%0F")
+		buf := make([]byte, 0, 128)
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = l.AppendFormat(buf[:0], marsTime)
+		})
+		assert.Equal(t, allocs, float64(0))
+	})
+}
+
+func BenchmarkLayoutFormat(b *testing.B) {
+	marsTime := planets.MarsDate(221, 8, 26, 14, 35, 0, 0)
+	b.Run("Compiled", func(b *testing.B) {
+		l := planets.MustCompileLayout(planets.MarsISO)
+		for b.Loop() {
+			l.Format(marsTime)
+		}
+	})
+	b.Run("ClockOnly", func(b *testing.B) {
+		l := planets.MustCompileLayout("%0V|%0L|%0F")
+		for b.Loop() {
+			l.Format(marsTime)
+		}
+	})
+}
+
+func BenchmarkLayoutParse(b *testing.B) {
+	l := planets.MustCompileLayout(planets.MarsISO)
+	for b.Loop() {
+		l.Parse("221=08=26T14|35|00")
+	}
+}
+
+func TestParseFixedWidth(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		tests := []struct {
+			layout   string
+			expected string
+		}{
+			{"%R%0M%0S%'T%0V%0L%0F", "2210826T143500"},
+			{"%R%0M%0S%'T%0V%0L%0F", "10101T000000"},
+			{"%R%0M%0S%'T%0V%0L%0F", "-30101T000000"},
+			{"%R%0M%0S", "1000000101"},
+			{"%+R%0M%0S", "+2210826"},
+			{"%R%0M%0S%0V%0L%0F%f0", "2210826143500000000005"},
+			{"%R=%M%0S", "221=826"},
+			{"%R=%M%0S", "221=1226"},
+			{"%R=%0M%0S%'T%V%0L", "221=0826T935"},
+			{"%R=%0W%WS", "221=325"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.expected, func(t *testing.T) {
+				marsTime, err := planets.MarsTime{}.Parse(tc.layout, tc.expected)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, marsTime.Format(tc.layout), tc.expected)
+			})
+		}
+	})
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			layout string
+			input  string
+		}{
+			{"%R%0M%0S", "0826"},
+			{"%R%0M%0S", "2212926"},
+			{"%R%0M%0S", "221=0826"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.input, func(t *testing.T) {
+				_, err := planets.MarsTime{}.Parse(tc.layout, tc.input)
+				assert.NotEqual(t, err, nil)
+			})
+		}
+	})
+	t.Run("PaddedTokensStopAtWidth", func(t *testing.T) {
+		_, err := planets.MarsTime{}.Parse("%R=%0M%0S", "221=826")
+		assert.NotEqual(t, err, nil)
+		marsTime, err := planets.MarsTime{}.Parse("%R=%0M=%0S", "221=8=26")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, marsTime.Format("%R=%0M=%0S"), "221=08=26")
+	})
+}
@@ -0,0 +1,33 @@
+package planets
+
+// Standard layouts for Format and Parse; examples are for Jovis, 221 Mina 26th
+// at 14|35|00 and nanofragment 5
+const (
+	// MarsISO is the layout of date and time in the style of ISO 8601,
+	// such as "221=08=26T14|35|00"
+	MarsISO = "%R=%0M=%0S%'T%0V|%0L|%0F"
+	// MarsISONano is MarsISO with all nine digits of nanofragment,
+	// such as "221=08=26T14|35|00.000000005"
+	MarsISONano = "%R=%0M=%0S%'T%0V|%0L|%0F.%f0"
+	// MarsISODate is the date of MarsISO, such as "221=08=26"
+	MarsISODate = "%R=%0M=%0S"
+	// MarsRFC is a layout for people in the style of RFC 1123,
+	// such as "Jov, 26 Min 221 14|35|00"
+	MarsRFC = "%nS, %0S %nM %R %0V|%0L|%0F"
+	// MarsKitchen is time of sol on twelve vinqua clock, such as "2:35 P"
+	MarsKitchen = "%V12:%0L %Vu"
+	// MarsCompact is MarsISO without separators, usable in file names,
+	// such as "2210826T143500"
+	MarsCompact = "%R%0M%0S%'T%0V%0L%0F"
+
+	// MarsCanonical is the canonical representation of MarsTime, such as
+	// "00221=08=26T14|35|00.000000005". Rotation is written with at least
+	// five digits, so for rotations 0 to 99999 every field has fixed width
+	// and comparing the strings byte by byte orders them like the times they
+	// stand for; other rotations, like "100000" or "-12345", are written
+	// with all their digits and parse back as well. Parse gives the first
+	// time of the written nanofragment (about 1.03 nanoseconds), which
+	// Format writes as the same string; times built by MarsDate or Parse
+	// round-trip exactly, others are truncated to their nanofragment.
+	MarsCanonical = "%05R=%0M=%0S%'T%0V|%0L|%0F.%f0"
+)
@@ -0,0 +1,91 @@
+package planets_test
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestStandardLayouts(t *testing.T) {
+	marsTime := planets.MarsDate(221, 8, 26, 14, 35, 0, 5)
+	tests := []struct {
+		layout   string
+		expected string
+	}{
+		{planets.MarsISO, "221=08=26T14|35|00"},
+		{planets.MarsISONano, "221=08=26T14|35|00.000000005"},
+		{planets.MarsISODate, "221=08=26"},
+		{planets.MarsRFC, "Jov, 26 Min 221 14|35|00"},
+		{planets.MarsKitchen, "2:35 P"},
+		{planets.MarsCompact, "2210826T143500"},
+		{planets.MarsCanonical, "00221=08=26T14|35|00.000000005"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.layout, func(t *testing.T) {
+			result := marsTime.Format(test.layout)
+			assert.Equal(t, result, test.expected)
+
+			parsed, err := marsTime.ParseWithOptions(test.layout, result, planets.ParseOptions{Strict: true, Reference: &marsTime})
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed.Format(test.layout), result)
+		})
+	}
+}
+
+func TestMarsCanonical(t *testing.T) {
+	r := rand.New(rand.NewSource(221))
+	var times []planets.MarsTime
+	for range 200 {
+		earthTime := time.Unix(r.Int63n(1<<40), r.Int63n(int64(time.Second))).UTC()
+		times = append(times, planets.NewMarsTime(&earthTime))
+		times = append(times, planets.MarsDate(r.Intn(100000), r.Intn(24)+1, r.Intn(27)+1, r.Intn(24), r.Intn(60), r.Intn(60), r.Intn(int(time.Second))))
+	}
+	times = append(times, planets.MarsDate(0, 1, 1, 0, 0, 0, 0), planets.MarsDate(99999, 24, 27, 23, 59, 59, int(time.Second)-1))
+
+	// outside of rotations 0 to 99999 strings are not sortable,
+	// but still round-trip
+	wide := []planets.MarsTime{
+		planets.MarsDate(100000, 8, 26, 14, 35, 0, 5),
+		planets.MarsDate(1234567, 24, 27, 23, 59, 59, int(time.Second)-1),
+		planets.MarsDate(-12345, 8, 26, 14, 35, 0, 5),
+		planets.MarsDate(-1, 1, 1, 0, 0, 0, 0),
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, mt := range append(slices.Clone(times), wide...) {
+			s := mt.Format(planets.MarsCanonical)
+			parsed, err := planets.MarsTime{}.ParseWithOptions(planets.MarsCanonical, s, planets.StrictParse)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed.Format(planets.MarsCanonical), s)
+			assert.Equal(t, parsed.After(mt), false)
+			assert.Equal(t, mt.Sub(parsed) < 2*time.Nanosecond, true)
+		}
+	})
+	t.Run("RoundTripExact", func(t *testing.T) {
+		for _, mt := range append(wide, planets.MarsDate(3, 24, 28, 23, 59, 59, 999999999)) {
+			parsed, err := planets.MarsTime{}.Parse(planets.MarsCanonical, mt.Format(planets.MarsCanonical))
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed, mt)
+		}
+		assert.Equal(t, planets.MarsDate(100000, 8, 26, 14, 35, 0, 5).Format(planets.MarsCanonical), "100000=08=26T14|35|00.000000005")
+		assert.Equal(t, planets.MarsDate(-12345, 8, 26, 14, 35, 0, 5).Format(planets.MarsCanonical), "-12345=08=26T14|35|00.000000005")
+	})
+	t.Run("Sortable", func(t *testing.T) {
+		byTime := slices.Clone(times)
+		slices.SortFunc(byTime, planets.MarsTime.Compare)
+		byString := slices.Clone(times)
+		slices.SortFunc(byString, func(a, b planets.MarsTime) int {
+			return strings.Compare(a.Format(planets.MarsCanonical), b.Format(planets.MarsCanonical))
+		})
+		for i := range byTime {
+			assert.Equal(t, byString[i].Format(planets.MarsCanonical), byTime[i].Format(planets.MarsCanonical))
+		}
+	})
+}
@@ -0,0 +1,237 @@
+package planets
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+)
+
+// Locale holds names and markers Format and Parse use for a language.
+// A Locale must not be modified once it is used or registered.
+type Locale struct {
+	// Name identifies the locale in the registry, such as "en" or "de"
+	Name string
+
+	// LongMonthNames and ShortMonthNames hold 24 names, from Sagittarius to Vrishika
+	LongMonthNames  []string
+	ShortMonthNames []string
+	// LongWeekSolNames and ShortWeekSolNames hold 7 names, from Solis to Saturni
+	LongWeekSolNames  []string
+	ShortWeekSolNames []string
+
+	// OrdinalSuffix gives text %oS writes after sol n, such as "th" for 5;
+	// nil writes no suffix
+	OrdinalSuffix format.OrdinalRule
+
+	// AM and PM are markers %Vu writes, such as "A" and "P";
+	// LowerAM and LowerPM are the ones %Vl writes
+	AM, PM           string
+	LowerAM, LowerPM string
+}
+
+// DefaultLocale is the "en" locale Format and Parse use unless told otherwise
+var DefaultLocale = &Locale{
+	Name:              "en",
+	LongMonthNames:    MarsTime{}.LongMonthNames(),
+	ShortMonthNames:   MarsTime{}.ShortMonthNames(),
+	LongWeekSolNames:  MarsTime{}.LongWeekSolNames(),
+	ShortWeekSolNames: MarsTime{}.ShortWeekSolNames(),
+	OrdinalSuffix:     format.EnglishOrdinal,
+	AM:                "A",
+	PM:                "P",
+	LowerAM:           "a",
+	LowerPM:           "p",
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]*Locale{}
+)
+
+func init() {
+	for _, l := range []*Locale{DefaultLocale, germanLocale, japaneseLocale, hindiLocale} {
+		if err := RegisterLocale(l); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterLocale adds l to the registry under l.Name, returning error
+// if the name is taken or if l lacks names or markers
+func RegisterLocale(l *Locale) error {
+	if err := l.validate(); err != nil {
+		return err
+	}
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	if locales[l.Name] != nil {
+		return fmt.Errorf("locale %q is already registered", l.Name)
+	}
+	locales[l.Name] = l
+	return nil
+}
+
+// LookupLocale returns registered locale called name
+func LookupLocale(name string) (l *Locale, ok bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	l, ok = locales[name]
+	return
+}
+
+func (l *Locale) validate() error {
+	if l.Name == "" {
+		return fmt.Errorf("locale needs a name")
+	}
+	for _, names := range []struct {
+		kind  string
+		names []string
+		count int
+	}{
+		{"long month", l.LongMonthNames, 24},
+		{"short month", l.ShortMonthNames, 24},
+		{"long weekSol", l.LongWeekSolNames, 7},
+		{"short weekSol", l.ShortWeekSolNames, 7},
+	} {
+		if len(names.names) != names.count {
+			return fmt.Errorf("locale %q has %d %s names instead of %d", l.Name, len(names.names), names.kind, names.count)
+		}
+		for i, name := range names.names {
+			if name == "" {
+				return fmt.Errorf("locale %q has empty %s name %d", l.Name, names.kind, i+1)
+			}
+		}
+	}
+	if l.AM == "" || l.PM == "" || l.LowerAM == "" || l.LowerPM == "" {
+		return fmt.Errorf("locale %q needs all AM/PM markers", l.Name)
+	}
+	if l.AM == l.PM || l.LowerAM == l.LowerPM {
+		return fmt.Errorf("locale %q needs different AM and PM markers", l.Name)
+	}
+	return nil
+}
+
+// ordinalSuffix returns text %oS writes after n
+func (l *Locale) ordinalSuffix(n int) string {
+	if l.OrdinalSuffix == nil {
+		return ""
+	}
+	return l.OrdinalSuffix(n)
+}
+
+// ParseMonthName is MarsTime.ParseMonthName that knows names of l
+func (l *Locale) ParseMonthName(s string, long bool) (n int, nameLen int, err error) {
+	names := l.ShortMonthNames
+	if long {
+		names = l.LongMonthNames
+	}
+	if n, nameLen, ok := parseName(s, names, false); ok {
+		return n, nameLen, nil
+	}
+	return 0, 0, fmt.Errorf("no matching month name found in %q", s)
+}
+
+// ParseWeekSolName is MarsTime.ParseWeekSolName that knows names of l
+func (l *Locale) ParseWeekSolName(s string, long bool) (n int, nameLen int, err error) {
+	names := l.ShortWeekSolNames
+	if long {
+		names = l.LongWeekSolNames
+	}
+	if n, nameLen, ok := parseName(s, names, false); ok {
+		return n, nameLen, nil
+	}
+	return 0, 0, fmt.Errorf("no matching weekSol name found in %q", s)
+}
+
+// FormatLocale is FormatE that writes names and markers of locale
+func (t MarsTime) FormatLocale(layout string, locale *Locale) (string, error) {
+	b, err := t.appendFormat(make([]byte, 0, len(layout)+16), layout, builtinToken, locale)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ParseLocale is Parse that reads names and markers of locale
+func (t MarsTime) ParseLocale(layout string, input string, locale *Locale) (MarsTime, error) {
+	return t.ParseWithOptions(layout, input, ParseOptions{Locale: locale})
+}
+
+// FormatLocale is Format that writes names and markers of locale
+func (l *Layout) FormatLocale(t MarsTime, locale *Locale) string {
+	return string(l.AppendFormatLocale(make([]byte, 0, len(l.layout)+16), t, locale))
+}
+
+// germanLocale uses German names of zodiac signs and weekdays
+var germanLocale = &Locale{
+	Name: "de",
+	LongMonthNames: []string{
+		"Schütze", "Dhanus", "Steinbock", "Makara", "Wassermann", "Kumbha",
+		"Fische", "Mina", "Widder", "Mesha", "Stier", "Vrishabha",
+		"Zwillinge", "Mithuna", "Krebs", "Karka", "Löwe", "Simha",
+		"Jungfrau", "Kanya", "Waage", "Tula", "Skorpion", "Vrishika",
+	},
+	ShortMonthNames: []string{
+		"Sch", "Dha", "Ste", "Mak", "Was", "Kum",
+		"Fis", "Min", "Wid", "Mes", "Sti", "Vrb",
+		"Zwi", "Mit", "Kre", "Kar", "Löw", "Sim",
+		"Jun", "Kan", "Waa", "Tul", "Sko", "Vrk",
+	},
+	LongWeekSolNames:  []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	ShortWeekSolNames: []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	OrdinalSuffix:     format.GermanOrdinal,
+	AM:                "VORM.",
+	PM:                "NACHM.",
+	LowerAM:           "vorm.",
+	LowerPM:           "nachm.",
+}
+
+// japaneseLocale uses Japanese names of zodiac signs and weekdays;
+// short month names are numbers of months, such as "4月"
+var japaneseLocale = &Locale{
+	Name: "ja",
+	LongMonthNames: []string{
+		"射手座", "ダヌス", "山羊座", "マカラ", "水瓶座", "クンバ",
+		"魚座", "ミーナ", "牡羊座", "メーシャ", "牡牛座", "ヴリシャバ",
+		"双子座", "ミトゥナ", "蟹座", "カルカ", "獅子座", "シンハ",
+		"乙女座", "カニヤー", "天秤座", "トゥラー", "蠍座", "ヴリシチカ",
+	},
+	ShortMonthNames: []string{
+		"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月",
+		"13月", "14月", "15月", "16月", "17月", "18月",
+		"19月", "20月", "21月", "22月", "23月", "24月",
+	},
+	LongWeekSolNames:  []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+	ShortWeekSolNames: []string{"日", "月", "火", "水", "木", "金", "土"},
+	OrdinalSuffix:     func(n int) string { return "日" },
+	AM:                "午前",
+	PM:                "午後",
+	LowerAM:           "午前",
+	LowerPM:           "午後",
+}
+
+// hindiLocale uses Hindi names of zodiac signs (Latin ones transliterated)
+// and weekdays; sols are written without ordinal suffix
+var hindiLocale = &Locale{
+	Name: "hi",
+	LongMonthNames: []string{
+		"सैजिटेरियस", "धनु", "कैप्रिकॉर्नस", "मकर", "एक्वेरियस", "कुंभ",
+		"पाइसीज़", "मीन", "एरीज़", "मेष", "टॉरस", "वृषभ",
+		"जेमिनी", "मिथुन", "कैंसर", "कर्क", "लियो", "सिंह",
+		"वर्गो", "कन्या", "लिब्रा", "तुला", "स्कॉर्पियो", "वृश्चिक",
+	},
+	ShortMonthNames: []string{
+		"सैजि", "धनु", "कैप्रि", "मकर", "एक्वे", "कुंभ",
+		"पाइ", "मीन", "एरी", "मेष", "टॉर", "वृष",
+		"जेमि", "मिथु", "कैंस", "कर्क", "लियो", "सिंह",
+		"वर्गो", "कन्या", "लिब्रा", "तुला", "स्कॉर्पि", "वृश्चि",
+	},
+	LongWeekSolNames:  []string{"रविवार", "सोमवार", "मंगलवार", "बुधवार", "गुरुवार", "शुक्रवार", "शनिवार"},
+	ShortWeekSolNames: []string{"रवि", "सोम", "मंगल", "बुध", "गुरु", "शुक्र", "शनि"},
+	AM:                "पूर्वाह्न",
+	PM:                "अपराह्न",
+	LowerAM:           "पूर्वाह्न",
+	LowerPM:           "अपराह्न",
+}
@@ -0,0 +1,132 @@
+package planets_test
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestLocales(t *testing.T) {
+	marsTime := planets.MarsDate(221, 8, 26, 14, 35, 0, 0)
+	layout := "%NS, %oS %NM %R (%nS, %nM) %V12|%0L %Vu"
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"en", "Jovis, 26th Mina 221 (Jov, Min) 2|35 P"},
+		{"de", "Donnerstag, 26. Mina 221 (Do, Min) 2|35 NACHM."},
+		{"ja", "木曜日, 26日 ミーナ 221 (木, 8月) 2|35 午後"},
+		{"hi", "गुरुवार, 26 मीन 221 (गुरु, मीन) 2|35 अपराह्न"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.locale, func(t *testing.T) {
+			locale, ok := planets.LookupLocale(test.locale)
+			assert.Equal(t, ok, true)
+
+			result, err := marsTime.FormatLocale(layout, locale)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, result, test.expected)
+			assert.Equal(t, planets.MustCompileLayout(layout).FormatLocale(marsTime, locale), test.expected)
+			lower, err := marsTime.FormatLocale("%Vl", locale)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, lower, locale.LowerPM)
+
+			parsed, err := marsTime.ParseLocale(layout, result, locale)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed, marsTime)
+
+			parsed, err = marsTime.ParseWithOptions(layout, result, planets.ParseOptions{Strict: true, Locale: locale})
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed, marsTime)
+		})
+	}
+}
+
+func TestDefaultLocale(t *testing.T) {
+	locale, ok := planets.LookupLocale("en")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, locale == planets.DefaultLocale, true)
+	assert.Equal(t, locale.LongMonthNames, planets.MarsTime{}.LongMonthNames())
+
+	_, ok = planets.LookupLocale("xx")
+	assert.Equal(t, ok, false)
+
+	n, nameLen, err := locale.ParseMonthName("Makara 5", true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, n, 4)
+	assert.Equal(t, nameLen, 6)
+}
+
+func TestRegisterLocale(t *testing.T) {
+	de, _ := planets.LookupLocale("de")
+	custom := *de
+	custom.Name = "de-CH"
+	custom.LongWeekSolNames = []string{"Sunntig", "Mäntig", "Ziischtig", "Mittwuch", "Dunschtig", "Friitig", "Samschtig"}
+	assert.Equal(t, planets.RegisterLocale(&custom), nil)
+
+	registered, ok := planets.LookupLocale("de-CH")
+	assert.Equal(t, ok, true)
+	result, err := planets.MarsDate(221, 8, 26, 0, 0, 0, 0).FormatLocale("%NS %oS %NM", registered)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, result, "Dunschtig 26. Mina")
+
+	assert.NotEqual(t, planets.RegisterLocale(&custom), nil)
+
+	broken := custom
+	broken.Name = "broken"
+	broken.ShortMonthNames = broken.ShortMonthNames[:12]
+	assert.NotEqual(t, planets.RegisterLocale(&broken), nil)
+
+	broken = custom
+	broken.Name = "broken"
+	broken.PM = broken.AM
+	assert.NotEqual(t, planets.RegisterLocale(&broken), nil)
+	_, ok = planets.LookupLocale("broken")
+	assert.Equal(t, ok, false)
+}
+
+func TestParseNumeralSystems(t *testing.T) {
+	expected := planets.MarsDate(221, 8, 26, 14, 35, 0, 0)
+	parsed, err := planets.MarsTime{}.Parse("%R=%0M=%0S%'T%0V|%0L", "२२१=०८=२६T१४|३५")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, expected)
+
+	hi, _ := planets.LookupLocale("hi")
+	parsed, err = planets.MarsTime{}.ParseLocale("%oS %NM %R, %V:%0L", "२६ मीन २२१, १४:३५", hi)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, expected)
+
+	parsed, err = planets.MarsTime{}.Parse("%R=%0M=%0S", "٢٢١=٠٨=٢٦")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, planets.MarsDate(221, 8, 26, 0, 0, 0, 0))
+
+	parsed, err = planets.MarsTime{}.Parse(planets.MarsCompact, "२२१०८२६T१४३५००")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, expected)
+
+	for _, layout := range []string{"%04R=%0M=%0S", "%4R=%0M=%0S"} {
+		parsed, err = planets.MarsTime{}.Parse(layout, "०२२१=०८=२६")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, parsed, planets.MarsDate(221, 8, 26, 0, 0, 0, 0))
+	}
+	parsed, err = planets.MarsTime{}.Parse("%4R=%0M=%0S", "२२१=०८=२६")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, planets.MarsDate(221, 8, 26, 0, 0, 0, 0))
+
+	for _, tc := range []struct {
+		layout string
+		input  string
+	}{
+		{"%R=%0M=%0S", "２２１=０８=２６"},
+		{"%R=%0M=%0S", "２２１=08=26"},
+		{"%04R=%0M=%0S", "०२२१=08=26"},
+		{planets.MarsCompact, "२२१०८२६T१४३५००"},
+		{"%R=%0M=%0S.%f0", "221=08=26.००००००००५"},
+	} {
+		_, err = planets.MarsTime{}.ParseWithOptions(tc.layout, tc.input, planets.StrictParse)
+		assert.NotEqual(t, err, nil)
+	}
+}
@@ -0,0 +1,134 @@
+package planets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+)
+
+// modifiedToken returns definition of token with format.Modifier at i of
+// layout, such as "%04R" or "%^NM", or nil if there is no modifier there;
+// on error end is where the fragment of layout with the modifier ends
+func modifiedToken(layout string, i int, lookup tokenLookup) (def *tokenDef, end int, err error) {
+	m, n, err := format.ParseModifier(layout[i+1:])
+	if err != nil {
+		end = i + 1
+		for end < len(layout) && strings.IndexByte("^,~#_0123456789", layout[end]) >= 0 {
+			end++
+		}
+		return nil, end, err
+	}
+	if n == 0 {
+		return nil, 0, nil
+	}
+	start := i + 1 + n
+	var base *tokenDef
+	for length := 4; length > 0 && base == nil; length-- {
+		if start+length <= len(layout) {
+			base = lookup("%" + layout[start:start+length])
+		}
+	}
+	if base == nil {
+		return nil, start, fmt.Errorf("no token follows modifier %q", layout[i+1:start])
+	}
+	end = start + len(base.token) - 1
+	switch {
+	case base.token == "%%" || base.token == "%'":
+		return nil, end, fmt.Errorf("token %q takes no modifier", base.token)
+	case m.Roman && base.value == nil:
+		return nil, end, fmt.Errorf("token %q is not a number, so it can not be written in Roman numerals", base.token)
+	case m.Pad == '0' && (base.value == nil || m.Roman):
+		return nil, end, fmt.Errorf("token %q is not written by digits, so it can not be zero padded", base.token)
+	}
+	return modify(base, layout[i:end], m), end, nil
+}
+
+// modify returns definition of token that is base changed by m
+func modify(base *tokenDef, token string, m format.Modifier) *tokenDef {
+	res := *base
+	res.token = token
+	res.description = base.description + ", modified by `" + m.String() + "`"
+	res.exampleKey = ""
+	if base.value == nil {
+		res.format = func(f formatFields, b []byte) []byte {
+			return m.Apply(base.format(f, b), len(b))
+		}
+		res.parse = func(st *parseState, def *tokenDef, input string) (int, int, error) {
+			return parsePadded(st, m, input, func(input string) (int, int, error) {
+				ignoreCase := st.opts.IgnoreCase
+				st.opts.IgnoreCase = ignoreCase || m.Case != format.CaseNone
+				defer func() { st.opts.IgnoreCase = ignoreCase }()
+				return base.parse(st, base, input)
+			})
+		}
+		return &res
+	}
+
+	if m.Roman {
+		res.width, res.pad = m.Width, ' '
+		res.variableWidth = false
+		res.parse = func(st *parseState, def *tokenDef, input string) (int, int, error) {
+			return parsePadded(st, m, input, func(input string) (int, int, error) {
+				return parseRomanToken(st, def, input)
+			})
+		}
+	} else if m.Width > 0 {
+		res.width, res.pad = m.Width, m.Pad
+		if res.pad == 0 {
+			res.pad = ' '
+		}
+		// rotation has no upper bound, so width is only its minimum
+		res.variableWidth = base.field == fieldRotation
+	}
+	res.format = func(f formatFields, b []byte) []byte {
+		return m.AppendNumber(b, base.value(f), base.width, base.pad, base.signed)
+	}
+	return &res
+}
+
+// parsePadded skips spaces m pads the token with before parsing it;
+// in strict mode there must be exactly as many as Format writes,
+// counting columns of format.DisplayWidth
+func parsePadded(st *parseState, m format.Modifier, input string, parse func(input string) (int, int, error)) (int, int, error) {
+	skipped := 0
+	if m.Width > 0 {
+		for skipped < len(input) && input[skipped] == ' ' {
+			skipped++
+		}
+	}
+	value, consumed, err := parse(input[skipped:])
+	if err != nil {
+		return 0, 0, err
+	}
+	if st.opts.Strict && skipped != max(0, m.Width-format.DisplayWidth(input[skipped:skipped+consumed])) {
+		return 0, 0, fmt.Errorf("expected token padded with spaces to width %d, got %q", m.Width, input[:skipped+consumed])
+	}
+	return value, skipped + consumed, nil
+}
+
+func parseRomanToken(st *parseState, def *tokenDef, input string) (value int, consumed int, err error) {
+	if def.signed {
+		if !strings.HasPrefix(input, "+") && !strings.HasPrefix(input, "-") {
+			return 0, 0, fmt.Errorf("expected '+' or '-' sign, got %q", input)
+		}
+		if input[0] == '+' {
+			consumed = 1
+		}
+	}
+	value, n, err := format.ParseRoman(input[consumed:])
+	if err != nil {
+		return 0, 0, err
+	}
+	consumed += n
+	if def.hi > def.lo {
+		if err = checkRange(def.field.name(), value, def.lo, def.hi); err != nil {
+			return 0, 0, err
+		}
+	}
+	if def.twelveHour {
+		st.vinquaRequiresAMPM = true
+		value %= 12
+	}
+	return value, consumed, nil
+}
@@ -0,0 +1,113 @@
+package planets_test
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestModifiers(t *testing.T) {
+	marsTime := planets.MarsDate(3, 4, 5, 14, 1, 2, 0)
+	tests := []struct {
+		layout   string
+		expected string
+	}{
+		{"%04R=%0M=%0S", "0003=04=05"},
+		{"%4R|%3M|%_3S", "   3|  4|  5"},
+		{"%05+R", "+0003"},
+		{"%^NM %,oS, %,nS", "MAKARA 5th, jov"},
+		{"%~NS", "Jovis"},
+		{"%8NM|", "  Makara|"},
+		{"%#R.%#M.%,#S", "III.IV.v"},
+		{"%#5M|", "   IV|"},
+		{"%#V12 %Vu", "II P"},
+		{"%^oS", "5TH"},
+		// registered tokens are matched before modifiers
+		{"%0M %_W %0V11", "04 13 02"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.layout, func(t *testing.T) {
+			result, err := marsTime.FormatE(test.layout)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, result, test.expected)
+
+			parsed, err := marsTime.ParseWithOptions(test.layout, result, planets.ParseOptions{Strict: true, Reference: &marsTime})
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed.Format(test.layout), result)
+		})
+	}
+}
+
+func TestModifiersParse(t *testing.T) {
+	marsTime := planets.MarsDate(203, 24, 5, 0, 0, 0, 0)
+	parsed, err := marsTime.Parse("%#R %#M %_4S", "cciii XXIV    5")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, marsTime)
+
+	parsed, err = marsTime.Parse("%^NM %S, %R", "VRISHIKA 5, 203")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, marsTime)
+
+	_, err = marsTime.ParseWithOptions("%06R%0M%0S", "00020324 05", planets.StrictParse)
+	assert.NotEqual(t, err, nil)
+	parsed, err = marsTime.Parse("%06R%0M%0S", "0002032405")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, marsTime)
+
+	t.Run("RotationWiderThanWidth", func(t *testing.T) {
+		for _, layout := range []string{"%04R=%0M=%0S", "%_4R=%0M=%0S", "%04R%0M%0S", "%04+R%0M%0S"} {
+			for _, rotation := range []int{203, 12345, -12345, 1234567} {
+				expected := planets.MarsDate(rotation, 8, 26, 0, 0, 0, 0)
+				formatted := expected.Format(layout)
+				for _, opts := range []planets.ParseOptions{{}, planets.StrictParse} {
+					parsed, err := planets.MarsTime{}.ParseWithOptions(layout, formatted, opts)
+					assert.Equal(t, err, nil)
+					assert.Equal(t, parsed, expected)
+				}
+			}
+		}
+		parsed, err := planets.MarsTime{}.ParseInto("%04R=%0M", "12345=08")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, parsed.Rotation(), 12345)
+	})
+
+	_, err = marsTime.Parse("%#M", "XXV")
+	assert.Equal(t, errors.Is(err, planets.ErrOutOfRange), true)
+
+	_, err = marsTime.ParseWithOptions("%R %6NM", "203 Kumbha", planets.StrictParse)
+	assert.NotEqual(t, err, nil)
+}
+
+func TestModifierErrors(t *testing.T) {
+	tests := []struct {
+		layout   string
+		fragment string
+	}{
+		{"%#NM", "%#NM"},
+		{"%05NM", "%05NM"},
+		{"%^%", "%^%"},
+		{"%^,NM", "%^,"},
+		{"%^Z", "%^"},
+		{"%99R", "%99"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.layout, func(t *testing.T) {
+			_, err := planets.MarsTime{}.FormatE(test.layout)
+			var formatErr *planets.FormatError
+			assert.Equal(t, errors.As(err, &formatErr), true)
+			assert.Equal(t, formatErr.Fragment, test.fragment)
+			assert.NotEqual(t, formatErr.Err, nil)
+		})
+	}
+}
+
+func TestModifiersExplain(t *testing.T) {
+	tokens, err := planets.MarsTime{}.Explain("%^NM")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, tokens, []planets.LayoutToken{{Offset: 0, Token: "%^NM", Example: "MAKARA", Description: "Full Month name, modified by `^`"}})
+}
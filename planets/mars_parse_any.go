@@ -0,0 +1,58 @@
+package planets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoLayouts is returned by ParseAny and ParseAnyExample when no layouts are given
+var ErrNoLayouts = errors.New("no layouts to parse with")
+
+// ParseAnyError describes failure of ParseAny and ParseAnyExample;
+// Errors holds error of each layout in order they were tried
+type ParseAnyError struct {
+	Input  string
+	Errors []error
+}
+
+func (e *ParseAnyError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "no layout matched %q", e.Input)
+	for i, err := range e.Errors {
+		fmt.Fprintf(&sb, "; [%d] %v", i, err)
+	}
+	return sb.String()
+}
+
+// Unwrap returns errors of individual layouts, so errors.Is and errors.As
+// look through all of them
+func (e *ParseAnyError) Unwrap() []error {
+	return e.Errors
+}
+
+// ParseAny parses input with each of layouts in order, returning result of
+// the first one that succeeds together with its index
+func (t MarsTime) ParseAny(layouts []string, input string) (mt MarsTime, index int, err error) {
+	return parseAny(layouts, input, t.Parse)
+}
+
+// ParseAnyExample is ParseAny that takes examples instead of layouts
+func (t MarsTime) ParseAnyExample(examples []string, input string) (mt MarsTime, index int, err error) {
+	return parseAny(examples, input, t.ParseExample)
+}
+
+func parseAny(layouts []string, input string, parse func(string, string) (MarsTime, error)) (MarsTime, int, error) {
+	if len(layouts) == 0 {
+		return MarsTime{}, -1, ErrNoLayouts
+	}
+	errs := make([]error, len(layouts))
+	for i, layout := range layouts {
+		mt, err := parse(layout, input)
+		if err == nil {
+			return mt, i, nil
+		}
+		errs[i] = err
+	}
+	return MarsTime{}, -1, &ParseAnyError{Input: input, Errors: errs}
+}
@@ -0,0 +1,55 @@
+package planets_test
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestParseAny(t *testing.T) {
+	layouts := []string{
+		planets.MarsISO,
+		"%R%0M%0S%'T%0V%0L%0F",
+		"%NS, %R %NM %oS @ %0V|%0L|%0F",
+	}
+	expected := planets.MarsDate(221, 8, 26, 14, 35, 0, 0)
+	t.Run("Matches", func(t *testing.T) {
+		for i, layout := range layouts {
+			marsTime, index, err := planets.MarsTime{}.ParseAny(layouts, expected.Format(layout))
+			assert.Equal(t, err, nil)
+			assert.Equal(t, index, i)
+			assert.Equal(t, marsTime, expected)
+		}
+	})
+	t.Run("FirstMatchWins", func(t *testing.T) {
+		_, index, err := planets.MarsTime{}.ParseAny([]string{"%R=%M=%S", "%R=%0M=%0S"}, "221=08=26")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, index, 0)
+	})
+	t.Run("Examples", func(t *testing.T) {
+		marsTime, index, err := planets.MarsTime{}.ParseAnyExample([]string{"203/04/05", "203=04=05T00|01|02"}, "221=08=26T14|35|00")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, index, 1)
+		assert.Equal(t, marsTime, expected)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		_, index, err := planets.MarsTime{}.ParseAny(layouts, "221=30=26T14|35|00")
+		assert.Equal(t, index, -1)
+		var anyErr *planets.ParseAnyError
+		assert.Equal(t, errors.As(err, &anyErr), true)
+		assert.Equal(t, len(anyErr.Errors), len(layouts))
+		for i, layoutErr := range anyErr.Errors {
+			var parseErr *planets.ParseError
+			assert.Equal(t, errors.As(layoutErr, &parseErr), true)
+			assert.Equal(t, parseErr.Layout, layouts[i])
+		}
+		assert.Equal(t, errors.Is(err, planets.ErrOutOfRange), true)
+
+		_, index, err = planets.MarsTime{}.ParseAny(nil, "221=08=26")
+		assert.Equal(t, index, -1)
+		assert.Equal(t, err, planets.ErrNoLayouts)
+	})
+}
@@ -0,0 +1,307 @@
+package planets
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+)
+
+// ParseOptions adjusts how strictly Parse matches input against layout;
+// the zero value gives the behavior of Parse
+type ParseOptions struct {
+	// Strict requires numbers to be written exactly as Format writes them:
+	// "%0" tokens need all their digits ("%0M" is "08", "%f0" has 9 digits),
+	// "%_" tokens need exactly two characters, other numbers can not have
+	// leading spaces and ordinal suffix must be the one of the locale
+	Strict bool
+
+	// IgnoreCase matches month and weekSol names regardless of case
+	IgnoreCase bool
+
+	// FlexibleSpace lets a run of whitespace in layout match any run of
+	// whitespace in input, including none, and ignores leading and
+	// trailing whitespace of input
+	FlexibleSpace bool
+
+	// AllowTrailing ignores input remaining after the layout is matched
+	AllowTrailing bool
+
+	// Reference, when set, provides values missing from input: those more
+	// significant than the most significant value in input are taken from
+	// Reference, the rest are zero, so "%NM %oS" gives the start of that
+	// sol in the rotation of Reference and "%0V|%0L" gives that layer of
+	// the sol of Reference. WeekSol without sol or week gives the first
+	// time on matching sol that is not before Reference.
+	Reference *MarsTime
+
+	// Locale gives names and markers of input, DefaultLocale when nil
+	Locale *Locale
+}
+
+var (
+	// StrictParse accepts only input exactly as written by Format
+	StrictParse = ParseOptions{Strict: true}
+
+	// LenientParse accepts input written by hand
+	LenientParse = ParseOptions{IgnoreCase: true, FlexibleSpace: true, AllowTrailing: true}
+)
+
+// ParseWithOptions is Parse that matches input according to opts
+func (t MarsTime) ParseWithOptions(layout string, input string, opts ParseOptions) (mt MarsTime, err error) {
+	l, err := CompileLayout(layout)
+	if err != nil {
+		return MarsTime{}, layoutParseError(layout, input, err)
+	}
+	return l.ParseWithOptions(input, opts)
+}
+
+// ParseInto is Parse that takes values missing from input from t;
+// see ParseOptions.Reference
+func (t MarsTime) ParseInto(layout string, input string) (mt MarsTime, err error) {
+	return t.ParseWithOptions(layout, input, ParseOptions{Reference: &t})
+}
+
+// fillFromReference sets values missing from input, returning weekSol
+// the result has to be moved to when input specifies only weekSol
+func (st *parseState) fillFromReference(ref MarsTime) (nextWeekSol MarsWeekSol, err error) {
+	rotation, month, sol := ref.date()
+	vinqua, layer, fragment, rem := ref.clock()
+	if st.set&fieldWeekSol != 0 && st.set&(fieldSol|fieldWeek) == 0 {
+		if st.set&(fieldRotation|fieldMonth) != 0 {
+			return 0, fmt.Errorf("%w: weekSol without sol or week can not be combined with rotation or month", ErrInsufficientData)
+		}
+		// start from sol of ref, marsTime moves forward to nextWeekSol
+		nextWeekSol = MarsWeekSol(st.weekSol)
+		st.weekSol = 0
+		st.sol = sol
+		st.set |= fieldSol
+	}
+
+	fields := []struct {
+		field     parsedField
+		value     *int
+		reference int
+		zero      int
+	}{
+		{fieldRotation, &st.rotation, rotation, 0},
+		{fieldMonth, &st.month, month, 1},
+		{fieldSol, &st.sol, sol, 1},
+		{fieldVinqua, &st.vinqua, vinqua, 0},
+		{fieldLayer, &st.layer, layer, 0},
+		{fieldFragment, &st.fragment, fragment, 0},
+		{fieldNanofragment, &st.rem, rem, 0},
+	}
+	// coarsest is index of the most significant value taken from input
+	coarsest := len(fields)
+	for i, f := range fields {
+		if st.set&f.field != 0 {
+			coarsest = i
+			break
+		}
+	}
+	for i, f := range fields {
+		if st.set&f.field != 0 {
+			continue
+		}
+		if i < coarsest {
+			*f.value = f.reference
+		} else {
+			*f.value = f.zero
+		}
+		st.set |= f.field
+	}
+	return nextWeekSol, nil
+}
+
+// locale returns locale of parsed input
+func (st *parseState) locale() *Locale {
+	if st.opts.Locale != nil {
+		return st.opts.Locale
+	}
+	return DefaultLocale
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// skipSpaces returns index of the first character of s at or after i
+// that is not whitespace
+func skipSpaces(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// parseNumeric parses number at start of input, written with width
+// and pad if width is not 0
+func (st *parseState) parseNumeric(width int, pad byte, input string) (value int, consumed int, err error) {
+	if !st.opts.Strict {
+		if width > 0 {
+			return format.ParseNumericMax(input, width)
+		}
+		return format.ParseNumeric(input)
+	}
+	if width == 0 {
+		if len(input) > 0 && isSpace(input[0]) {
+			return 0, 0, fmt.Errorf("expected numeric value without leading spaces, got %q", input)
+		}
+		value, consumed, err = format.ParseNumeric(input)
+		if err == nil {
+			err = st.checkUnpadded(input[:consumed])
+		}
+		return
+	}
+	field := prefix(input, width)
+	if err := st.checkDigits(field); err != nil {
+		return 0, 0, err
+	}
+	if len(field) < width {
+		return 0, 0, fmt.Errorf("expected %d characters, got %q", width, input)
+	}
+	if pad == '0' && field[0] == ' ' {
+		return 0, 0, fmt.Errorf("expected %d digits, got %q", width, field)
+	}
+	if pad == ' ' && zeroPadded(field) {
+		return 0, 0, fmt.Errorf("expected number padded with spaces, got %q", field)
+	}
+	value, consumed, err = format.ParseNumeric(field)
+	if err == nil && consumed != width {
+		err = fmt.Errorf("expected %d characters of number, got %q", width, field)
+	}
+	return
+}
+
+// checkDigits rejects in strict mode number written with digits
+// other than ASCII, which Format does not write
+func (st *parseState) checkDigits(number string) error {
+	if !st.opts.Strict {
+		return nil
+	}
+	for i := 0; i < len(number); i++ {
+		if number[i] >= utf8.RuneSelf {
+			return fmt.Errorf("expected ASCII digits, got %q", number)
+		}
+	}
+	return nil
+}
+
+// checkUnpadded rejects in strict mode number of token without padding
+// written with leading zeros or digits Format does not write
+func (st *parseState) checkUnpadded(number string) error {
+	if st.opts.Strict && zeroPadded(number) {
+		return fmt.Errorf("expected number without leading zeros, got %q", number)
+	}
+	return st.checkDigits(number)
+}
+
+// prefix returns up to n first characters of s
+func prefix(s string, n int) string {
+	i := 0
+	for ; n > 0 && i < len(s); n-- {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return s[:i]
+}
+
+// zeroPadded reports whether number at start of s, after spaces and sign,
+// has a leading zero followed by more digits, which Format writes
+// only for zero padded tokens
+func zeroPadded(s string) bool {
+	i := skipSpaces(s, 0)
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	return i+1 < len(s) && s[i] == '0' && s[i+1] >= '0' && s[i+1] <= '9'
+}
+
+// reserveDigits shortens input so that number at its start leaves reserve
+// digits to tokens following it, as in "%R%0M%0S" matching "2210826"
+func reserveDigits(input string, reserve int) (string, error) {
+	i := 0
+	for i < len(input) && input[i] == ' ' {
+		i++
+	}
+	if i < len(input) && (input[i] == '+' || input[i] == '-') {
+		i++
+	}
+	_, digits := format.ScanDigits(input[i:], len(input))
+	if digits <= reserve {
+		return "", fmt.Errorf("expected more than %d digits, got %q", reserve, input)
+	}
+	length, _ := format.ScanDigits(input[i:], digits-reserve)
+	return input[:i+length], nil
+}
+
+// skipOrdinalSuffix returns length of ordinal suffix of n at start of input
+func (st *parseState) skipOrdinalSuffix(n int, input string) (consumed int, err error) {
+	suffix := st.locale().ordinalSuffix(n)
+	if !st.opts.Strict {
+		if n, ok := prefixFold(input, suffix, true); ok {
+			return n, nil
+		}
+		// skip two characters of a suffix like "th" written for "3rd"
+		for range 2 {
+			if consumed < len(input) {
+				_, size := utf8.DecodeRuneInString(input[consumed:])
+				consumed += size
+			}
+		}
+		return consumed, nil
+	}
+	n, ok := prefixFold(input, suffix, st.opts.IgnoreCase)
+	if !ok {
+		return 0, fmt.Errorf("expected ordinal suffix %q, got %q", suffix, input)
+	}
+	return n, nil
+}
+
+// prefixFold reports whether s starts with prefix, comparing characters
+// under Unicode case folding when ignoreCase is set; n is length of the
+// matching part of s, which can differ from length of prefix
+func prefixFold(s string, prefix string, ignoreCase bool) (n int, ok bool) {
+	if !ignoreCase {
+		return len(prefix), strings.HasPrefix(s, prefix)
+	}
+	for _, p := range prefix {
+		if n >= len(s) {
+			return 0, false
+		}
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if r != p && !foldEqual(r, p) {
+			return 0, false
+		}
+		n += size
+	}
+	return n, true
+}
+
+// foldEqual reports whether r and p are the same under simple case folding
+func foldEqual(r rune, p rune) bool {
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f == p {
+			return true
+		}
+	}
+	return false
+}
+
+// parseName returns 1-based index of the longest name from names
+// at start of s, and length of the name in s
+func parseName(s string, names []string, ignoreCase bool) (n int, nameLen int, ok bool) {
+	for i, name := range names {
+		if length, match := prefixFold(s, name, ignoreCase); match && length > nameLen {
+			n, nameLen, ok = i+1, length, true
+		}
+	}
+	return
+}
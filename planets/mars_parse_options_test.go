@@ -0,0 +1,171 @@
+package planets_test
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestParseWithOptions(t *testing.T) {
+	t.Run("Strict", func(t *testing.T) {
+		tests := []struct {
+			layout string
+			input  string
+			valid  bool
+			// where invalid input fails
+			inputOffset int
+			token       string
+		}{
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "221=08=26T14|35|00", true, 0, ""},
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "221=8=26T14|35|00", false, 4, "%0M"},
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "221= 8=26T14|35|00", false, 4, "%0M"},
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", " 221=08=26T14|35|00", false, 0, "%R"},
+			{"%R %nM %_S", "207 Lib  7", true, 0, ""},
+			{"%R %nM %_S", "207 Lib 14", true, 0, ""},
+			{"%R %nM %_S", "207 Lib 7", false, 8, "%_S"},
+			{"%R %nM %_S", "207 Lib 07", false, 8, "%_S"},
+			{"%R %nM %_S %_V", "207 Lib 14 00", false, 11, "%_V"},
+			{"%R %nM %_S %_V", "207 Lib 14  0", true, 0, ""},
+			{"%4R=%0M=%0S", " 221=08=26", true, 0, ""},
+			{"%4R=%0M=%0S", "0221=08=26", false, 0, "%4R"},
+			{"%04R=%0M=%0S", "0221=08=26", true, 0, ""},
+			{"%R %M %S", "207 21  7", false, 7, "%S"},
+			{"%R %NM %oS", "201 Dhanus 3rd", true, 0, ""},
+			{"%R %NM %oS", "201 Dhanus 3th", false, 11, "%oS"},
+			{"%R %NM %oS", "207 Libra 11th", true, 0, ""},
+			{"%R %NM %oS", "207 Libra 21th", false, 10, "%oS"},
+			{"%R=%0M=%0S.%f0", "221=08=26.000000005", true, 0, ""},
+			{"%R=%0M=%0S.%f0", "221=08=26.5", false, 10, "%f0"},
+			{"%R=%M=%S", "221=08=26", false, 4, "%M"},
+			{"%R=%0M=%0S", "0221=08=26", false, 0, "%R"},
+			{"%R=%0M=%oS", "221=08=03rd", false, 7, "%oS"},
+			{"%+R=%0M=%0S", "+0221=08=26", false, 0, "%+R"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.input, func(t *testing.T) {
+				marsTime, err := planets.MarsTime{}.ParseWithOptions(tc.layout, tc.input, planets.StrictParse)
+				assert.Equal(t, err == nil, tc.valid)
+				if tc.valid {
+					assert.Equal(t, marsTime.Format(tc.layout), tc.input)
+				} else {
+					var parseErr *planets.ParseError
+					assert.Equal(t, errors.As(err, &parseErr), true)
+					assert.Equal(t, parseErr.InputOffset, tc.inputOffset)
+					assert.Equal(t, parseErr.Token, tc.token)
+				}
+			})
+		}
+	})
+	t.Run("Lenient", func(t *testing.T) {
+		tests := []struct {
+			layout   string
+			input    string
+			expected string
+		}{
+			{"%NS, %R %NM %oS", "jovis, 221 MINA 26th", "Jovis, 221 Mina 26th"},
+			{"%NS, %R %NM %oS", "  Jovis,   221\tMina 26TH  ", "Jovis, 221 Mina 26th"},
+			{"%NS, %R %NM %oS", "Jovis,221 Mina 26th", "Jovis, 221 Mina 26th"},
+			{"%NS, %R %NM %oS", "Jovis, 221 Mina 26th (approximately)", "Jovis, 221 Mina 26th"},
+			{"%R %nM %_S", "207 lib 7", "207 Lib  7"},
+			{"%R %nM %_S %Vu", "207 LIB  7 p", "207 Lib  7 P"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.input, func(t *testing.T) {
+				marsTime, err := planets.MarsTime{}.ParseWithOptions(tc.layout, tc.input, planets.LenientParse)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, marsTime.Format(tc.layout), tc.expected)
+			})
+		}
+	})
+	t.Run("DefaultMatchesParse", func(t *testing.T) {
+		for _, input := range []string{"201 Dhanus 3th", "201 dhanus 3rd", "201 Dhanus 3rd !"} {
+			expected, expectedErr := planets.MarsTime{}.Parse("%R %NM %oS", input)
+			marsTime, err := planets.MarsTime{}.ParseWithOptions("%R %NM %oS", input, planets.ParseOptions{})
+			assert.Equal(t, marsTime, expected)
+			assert.Equal(t, err, expectedErr)
+		}
+	})
+	t.Run("SeparateOptions", func(t *testing.T) {
+		_, err := planets.MarsTime{}.ParseWithOptions("%R %NM %oS", "201 DHANUS 3rd", planets.ParseOptions{IgnoreCase: true})
+		assert.Equal(t, err, nil)
+		_, err = planets.MarsTime{}.ParseWithOptions("%R=%NM %oS", "201 = Dhanus 3rd", planets.ParseOptions{IgnoreCase: true})
+		assert.Equal(t, errors.Is(err, planets.ErrLiteralMismatch), true)
+		_, err = planets.MarsTime{}.ParseWithOptions("%R %NM %oS", "201 Dhanus 3rd!", planets.ParseOptions{AllowTrailing: true})
+		assert.Equal(t, err, nil)
+		_, err = planets.MarsTime{}.ParseWithOptions("%R %NM %oS", "201 DHANUS 3RD", planets.ParseOptions{Strict: true, IgnoreCase: true})
+		assert.Equal(t, err, nil)
+	})
+	t.Run("CompiledLayout", func(t *testing.T) {
+		l := planets.MustCompileLayout("%R=%0M=%0S")
+		_, err := l.ParseWithOptions("221=8=26", planets.StrictParse)
+		assert.NotEqual(t, err, nil)
+		_, err = l.ParseWithOptions(" 221 = 08 = 26", planets.LenientParse)
+		assert.NotEqual(t, err, nil)
+		_, err = l.ParseWithOptions(" 221=08=26 ", planets.LenientParse)
+		assert.Equal(t, err, nil)
+	})
+}
+
+func TestParseInto(t *testing.T) {
+	layout := planets.MarsISO
+	reference := planets.MarsDate(221, 8, 26, 10, 20, 30, 0)
+	t.Run("FillsMissingValues", func(t *testing.T) {
+		tests := []struct {
+			layout   string
+			input    string
+			expected string
+		}{
+			{"", "", "221=08=26T10|20|30"},
+			{"%0V|%0L", "14|35", "221=08=26T14|35|00"},
+			{"%0M=%0S", "03=04", "221=03=04T00|00|00"},
+			{"%0S %0F", "03 17", "221=08=03T00|00|17"},
+			{"%R", "222", "222=01=01T00|00|00"},
+			{"%R=%0W=%WS", "222=03=2", "222=01=16T00|00|00"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.layout, func(t *testing.T) {
+				marsTime, err := reference.ParseInto(tc.layout, tc.input)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, marsTime.Format(layout), tc.expected)
+			})
+		}
+	})
+	t.Run("NextWeekSol", func(t *testing.T) {
+		tests := []struct {
+			layout   string
+			input    string
+			expected string
+		}{
+			{"%NS %0V|%0L", "Jovis 14|35", "221=08=26T14|35|00"},
+			{"%NS %0V|%0L", "Jovis 09|00", "221=09=05T09|00|00"},
+			{"%NS", "Veneris", "221=08=27T00|00|00"},
+			{"%NS", "Solis", "221=09=01T00|00|00"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.input, func(t *testing.T) {
+				marsTime, err := reference.ParseInto(tc.layout, tc.input)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, marsTime.Format(layout), tc.expected)
+			})
+		}
+	})
+	t.Run("Errors", func(t *testing.T) {
+		_, err := reference.ParseInto("%R %NS", "221 Jovis")
+		assert.Equal(t, errors.Is(err, planets.ErrInsufficientData), true)
+		_, err = reference.ParseInto("%0M=%0S", "06=28")
+		assert.Equal(t, errors.Is(err, planets.ErrOutOfRange), true)
+		_, err = planets.MarsTime{}.Parse("%0V|%0L", "14|35")
+		assert.Equal(t, errors.Is(err, planets.ErrInsufficientData), true)
+	})
+	t.Run("Options", func(t *testing.T) {
+		marsTime, err := planets.MarsTime{}.ParseWithOptions("%NS %0V|%0L", "jovis 14|35", planets.ParseOptions{IgnoreCase: true, Reference: &reference})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, marsTime.Format(layout), "221=08=26T14|35|00")
+		marsTime, err = planets.MustCompileLayout("%0V|%0L").ParseWithOptions("14|35", planets.ParseOptions{Reference: &reference})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, marsTime.Format(layout), "221=08=26T14|35|00")
+	})
+}
@@ -1,7 +1,10 @@
 package planets_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
@@ -503,3 +506,498 @@ func TestMarsDateTime(t *testing.T) {
 		assert.Equal(t, earthTime, marsTime.Time())
 	})
 }
+
+func TestMarsTimeLeapSol(t *testing.T) {
+	t.Run("SolsInMonthOfRotation", func(t *testing.T) {
+		assert.Equal(t, planets.MarsTime{}.SolsInMonthOfRotation(221, 24), 28)
+		assert.Equal(t, planets.MarsTime{}.SolsInMonthOfRotation(222, 24), 27)
+		assert.Equal(t, planets.MarsTime{}.SolsInMonthOfRotation(221, 23), 28)
+		assert.Equal(t, planets.MarsTime{}.SolsInMonthOfRotation(221, 18), 27)
+	})
+	t.Run("Params", func(t *testing.T) {
+		// rotation 0 is a leap rotation, so its last sol is Vrishika 28th
+		rotation, month, sol, _, _, _, _ := planets.MarsTime{TotalSols: 668}.Params()
+		assert.Equal(t, rotation, 0)
+		assert.Equal(t, month, 24)
+		assert.Equal(t, sol, 28)
+
+		rotation, month, sol, _, _, _, _ = planets.MarsTime{TotalSols: 669}.Params()
+		assert.Equal(t, rotation, 1)
+		assert.Equal(t, month, 1)
+		assert.Equal(t, sol, 1)
+	})
+	t.Run("Format", func(t *testing.T) {
+		assert.Equal(t, planets.MarsTime{TotalSols: 668}.Format("%R %NM %oS %NS"), "0 Vrishika 28th Saturni")
+	})
+}
+
+func TestMarsDate(t *testing.T) {
+	layout := "%R=%0M=%0S%'T%0V|%0L|%0F.%f0"
+	t.Run("Components", func(t *testing.T) {
+		tests := []struct {
+			rotation, month, sol, vinqua, layer, fragment, nanofragment int
+			expected                                                    string
+		}{
+			{221, 8, 26, 14, 35, 0, 0, "221=08=26T14|35|00.000000000"},
+			{201, 2, 3, 4, 5, 6, 712563515, "201=02=03T04|05|06.712563515"},
+			{1, 1, 1, 0, 0, 0, 0, "1=01=01T00|00|00.000000000"},
+			{221, 24, 28, 23, 59, 59, 999999999, "221=24=28T23|59|59.999999999"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.expected, func(t *testing.T) {
+				mt := planets.MarsDate(tc.rotation, tc.month, tc.sol, tc.vinqua, tc.layer, tc.fragment, tc.nanofragment)
+				assert.Equal(t, mt.Format(layout), tc.expected)
+			})
+		}
+	})
+	t.Run("Normalization", func(t *testing.T) {
+		tests := []struct {
+			name                                                        string
+			rotation, month, sol, vinqua, layer, fragment, nanofragment int
+			expected                                                    string
+		}{
+			{"SolIntoNextMonth", 221, 1, 29, 0, 0, 0, 0, "221=02=01T00|00|00.000000000"},
+			{"SolIntoNextMonthAfterShortMonth", 221, 6, 28, 0, 0, 0, 0, "221=07=01T00|00|00.000000000"},
+			{"MonthIntoNextRotation", 221, 25, 1, 0, 0, 0, 0, "222=01=01T00|00|00.000000000"},
+			{"ZeroMonth", 221, 0, 1, 0, 0, 0, 0, "220=24=01T00|00|00.000000000"},
+			{"ZeroSol", 221, 2, 0, 0, 0, 0, 0, "221=01=28T00|00|00.000000000"},
+			{"VinquaIntoNextSol", 221, 1, 1, 24, 0, 0, 0, "221=01=02T00|00|00.000000000"},
+			{"NegativeLayer", 221, 1, 2, 0, -1, 0, 0, "221=01=01T23|59|00.000000000"},
+			{"NanofragmentCarry", 221, 1, 1, 0, 0, 59, 1_000_000_001, "221=01=01T00|01|00.000000001"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				mt := planets.MarsDate(tc.rotation, tc.month, tc.sol, tc.vinqua, tc.layer, tc.fragment, tc.nanofragment)
+				assert.Equal(t, mt.Format(layout), tc.expected)
+			})
+		}
+	})
+	t.Run("MatchesParse", func(t *testing.T) {
+		parsed, err := planets.MarsTime{}.Parse(layout, "207=21=14T16|14|10.000000005")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, parsed, planets.MarsDate(207, 21, 14, 16, 14, 10, 5))
+	})
+}
+
+func TestMarsTimeBeforeEpoch(t *testing.T) {
+	t.Run("Params", func(t *testing.T) {
+		// rotation -1 is odd, thus has the leap Vrishika 28th
+		rotation, month, sol, _, _, _, _ := planets.MarsTime{TotalSols: -1}.Params()
+		assert.Equal(t, rotation, -1)
+		assert.Equal(t, month, 24)
+		assert.Equal(t, sol, 28)
+
+		rotation, month, sol, _, _, _, _ = planets.MarsTime{TotalSols: -669}.Params()
+		assert.Equal(t, rotation, -1)
+		assert.Equal(t, month, 1)
+		assert.Equal(t, sol, 1)
+
+		// rotation -2 is even, thus without leap Vrishika 28th
+		rotation, month, sol, _, _, _, _ = planets.MarsTime{TotalSols: -670}.Params()
+		assert.Equal(t, rotation, -2)
+		assert.Equal(t, month, 24)
+		assert.Equal(t, sol, 27)
+
+		rotation, month, sol, vinqua, _, _, _ := planets.MarsTime{TotalSols: 0, DurationOfCurrentSol: -planets.Vinqua}.Params()
+		assert.Equal(t, rotation, -1)
+		assert.Equal(t, month, 24)
+		assert.Equal(t, sol, 28)
+		assert.Equal(t, vinqua, 23)
+	})
+	t.Run("NewMarsTime", func(t *testing.T) {
+		tests := []struct {
+			earth    string
+			expected string
+		}{
+			{"1609-03-11T18:40:34Z", "0=01=01T00|00|00"},
+			{"1609-03-11T18:40:33Z", "-1=24=28T23|59|59"},
+			{"1604-10-09T00:00:00Z", "-3=16=17T10|13|57"},
+			{"1000-01-01T00:00:00Z", "-324=03=18T10|33|47"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.earth, func(t *testing.T) {
+				earthTime, err := time.Parse(time.RFC3339, tc.earth)
+				assert.Equal(t, err, nil)
+				marsTime := planets.NewMarsTime(&earthTime)
+				assert.Equal(t, marsTime.Format(planets.MarsISO), tc.expected)
+				assert.Equal(t, marsTime.Time(), earthTime)
+			})
+		}
+	})
+	t.Run("FormatAndParse", func(t *testing.T) {
+		tests := []struct {
+			layout   string
+			expected string
+		}{
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "-3=16=17T10|13|57"},
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "0=01=01T00|00|00"},
+			{"%+R=%0M=%0S%'T%0V|%0L|%0F", "-324=03=18T10|33|47"},
+			{"%+R=%0M=%0S%'T%0V|%0L|%0F", "+221=08=26T14|35|00"},
+			{"%+R=%0M=%0S%'T%0V|%0L|%0F", "+0=01=01T00|00|00"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.expected, func(t *testing.T) {
+				marsTime, err := planets.MarsTime{}.Parse(tc.layout, tc.expected)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, marsTime.Format(tc.layout), tc.expected)
+			})
+		}
+		t.Run("Plus", func(t *testing.T) {
+			marsTime, err := planets.MarsTime{}.Parse("%R=%0M=%0S", "+221=08=26")
+			assert.Equal(t, err, nil)
+			assert.Equal(t, marsTime.Format("%R=%0M=%0S"), "221=08=26")
+		})
+		t.Run("MissingSign", func(t *testing.T) {
+			_, err := planets.MarsTime{}.Parse("%+R=%0M=%0S", "221=08=26")
+			assert.NotEqual(t, err, nil)
+		})
+	})
+}
+
+// paramsByWalking decomposes totalSols sol by sol, as reference for Params
+func paramsByWalking(totalSols int) (rotation int, month int, sol int) {
+	mt := planets.MarsTime{}
+	month = 1
+	sol = totalSols
+	for sol < 0 {
+		rotation -= 1
+		sol += mt.SolsInRotation(rotation)
+	}
+	for sol >= mt.SolsInRotation(rotation) {
+		sol -= mt.SolsInRotation(rotation)
+		rotation += 1
+	}
+	for sol >= mt.SolsInMonthOfRotation(rotation, month) {
+		sol -= mt.SolsInMonthOfRotation(rotation, month)
+		month += 1
+	}
+	return rotation, month, sol + 1
+}
+
+func TestMarsTimeParamsMatchesCalendar(t *testing.T) {
+	t.Run("AroundEpoch", func(t *testing.T) {
+		for totalSols := -3000; totalSols <= 3000; totalSols++ {
+			rotation, month, sol, _, _, _, _ := planets.MarsTime{TotalSols: totalSols}.Params()
+			expectedRotation, expectedMonth, expectedSol := paramsByWalking(totalSols)
+			if rotation != expectedRotation || month != expectedMonth || sol != expectedSol {
+				t.Fatalf("Params(%d) = %d=%d=%d, want %d=%d=%d", totalSols, rotation, month, sol, expectedRotation, expectedMonth, expectedSol)
+			}
+		}
+	})
+	t.Run("RotationBoundaries", func(t *testing.T) {
+		// first and last sol of each rotation across several leap cycles
+		for rotation := -1100; rotation <= 1100; rotation++ {
+			first := planets.MarsDate(rotation, 1, 1, 0, 0, 0, 0)
+			r, m, s, _, _, _, _ := first.Params()
+			assert.Equal(t, [3]int{r, m, s}, [3]int{rotation, 1, 1})
+
+			last := first.AddSols(first.SolsInRotation(rotation) - 1)
+			r, m, s, _, _, _, _ = last.Params()
+			assert.Equal(t, [3]int{r, m, s}, [3]int{rotation, 24, last.SolsInMonthOfRotation(rotation, 24)})
+		}
+	})
+	t.Run("FarFromEpoch", func(t *testing.T) {
+		for _, rotation := range []int{1_000_000, -1_000_000, 123_456_789, -987_654_321} {
+			mt := planets.MarsDate(rotation, 17, 9, 1, 2, 3, 0)
+			assert.Equal(t, mt.Format(planets.MarsISO), fmt.Sprintf("%d=17=09T01|02|03", rotation))
+		}
+	})
+}
+
+func BenchmarkMarsTimeParams(b *testing.B) {
+	for _, rotation := range []int{221, 100_000, 10_000_000} {
+		mt := planets.MarsDate(rotation, 8, 26, 14, 35, 0, 0)
+		b.Run(fmt.Sprint(rotation), func(b *testing.B) {
+			for b.Loop() {
+				mt.Params()
+			}
+		})
+	}
+}
+
+func BenchmarkMarsTimeFormat(b *testing.B) {
+	for _, rotation := range []int{221, 100_000, 10_000_000} {
+		mt := planets.MarsDate(rotation, 8, 26, 14, 35, 0, 0)
+		b.Run(fmt.Sprint(rotation), func(b *testing.B) {
+			for b.Loop() {
+				mt.Format(planets.MarsISO)
+			}
+		})
+	}
+}
+
+func BenchmarkMarsTimeParse(b *testing.B) {
+	for _, input := range []string{"221=08=26T14|35|00", "100000=08=26T14|35|00", "10000000=08=26T14|35|00"} {
+		b.Run(input, func(b *testing.B) {
+			for b.Loop() {
+				planets.MarsTime{}.Parse(planets.MarsISO, input)
+			}
+		})
+	}
+}
+
+func TestMarsTimeConversionRoundTrip(t *testing.T) {
+	epoch := time.Date(1609, time.March, 11, 18, 40, 34, 0, time.UTC)
+	t.Run("WholeSols", func(t *testing.T) {
+		for _, sols := range []int{0, 1, -1, 100_000, -100_000} {
+			earthTime := epoch.Add(time.Duration(sols) * planets.Sol)
+			assert.Equal(t, planets.NewMarsTime(&earthTime), planets.MarsTime{TotalSols: sols})
+		}
+		// 312500 sols are exactly 27742263777 seconds
+		for _, cycles := range []int64{1, -1, 7, -7} {
+			earthTime := time.Unix(epoch.Unix()+cycles*27_742_263_777, 0)
+			assert.Equal(t, planets.NewMarsTime(&earthTime), planets.MarsTime{TotalSols: int(cycles) * 312_500})
+		}
+	})
+	t.Run("Extremes", func(t *testing.T) {
+		tests := []time.Time{
+			time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(9999, time.December, 31, 23, 59, 59, 999_999_999, time.UTC),
+			time.Date(-1_000_000, time.June, 15, 12, 0, 0, 1, time.UTC),
+			time.Date(1_000_000_000, time.June, 15, 12, 0, 0, 1, time.UTC),
+			time.Unix(1<<62, 999_999_999).UTC(),
+			time.Unix(-1<<62, 1).UTC(),
+			time.Unix(math.MaxInt64, 999_999_999).UTC(),
+			time.Unix(math.MinInt64, 0).UTC(),
+			time.Unix(math.MaxInt64-27_742_263_777, 0).UTC(),
+		}
+		for _, earthTime := range tests {
+			t.Run(earthTime.String(), func(t *testing.T) {
+				marsTime := planets.NewMarsTime(&earthTime)
+				assert.Equal(t, marsTime.Time(), earthTime)
+			})
+		}
+	})
+	t.Run("ExtremeSols", func(t *testing.T) {
+		for _, earthTime := range []time.Time{
+			time.Unix(math.MaxInt64, 999_999_999),
+			time.Unix(math.MinInt64, 0),
+			time.Unix(math.MaxInt64-27_742_263_777, 1),
+			time.Unix(math.MinInt64+27_742_263_777, 1),
+		} {
+			ns := new(big.Int).Sub(big.NewInt(earthTime.Unix()), big.NewInt(epoch.Unix()))
+			ns.Mul(ns, big.NewInt(int64(time.Second)))
+			ns.Add(ns, big.NewInt(int64(earthTime.Nanosecond())))
+			sols, rem := new(big.Int).DivMod(ns, big.NewInt(int64(planets.Sol)), new(big.Int))
+			expected := planets.MarsTime{TotalSols: int(sols.Int64()), DurationOfCurrentSol: time.Duration(rem.Int64())}
+			assert.Equal(t, planets.NewMarsTime(&earthTime), expected)
+		}
+	})
+	t.Run("Nanoseconds", func(t *testing.T) {
+		base := time.Date(2025, time.April, 14, 8, 48, 29, 0, time.UTC)
+		for ns := 0; ns < 5000; ns += 7 {
+			earthTime := base.Add(time.Duration(ns))
+			marsTime := planets.NewMarsTime(&earthTime)
+			if !marsTime.Time().Equal(earthTime) {
+				t.Fatalf("round trip of %v gave %v", earthTime, marsTime.Time())
+			}
+		}
+	})
+	t.Run("OtherLocation", func(t *testing.T) {
+		earthTime := time.Date(2025, time.April, 14, 8, 48, 29, 5, time.FixedZone("UTC+2", 2*60*60))
+		marsTime := planets.NewMarsTime(&earthTime)
+		assert.Equal(t, marsTime.Time(), earthTime.UTC())
+	})
+}
+
+func TestMarsTimeFormatE(t *testing.T) {
+	marsTime := planets.MarsDate(221, 8, 26, 14, 35, 0, 500_000_000)
+	t.Run("Valid", func(t *testing.T) {
+		res, err := marsTime.FormatE("%NS, %R %NM %oD @ %0V|%0L|%0F.%f")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, res, "Jovis, 221 Mina 26th @ 14|35|00.5")
+	})
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			layout   string
+			offset   int
+			fragment string
+		}{
+			{"%0 %1", 0, "%0"},
+			{"the %0A mistake", 4, "%0A"},
+			{"%R=%Q", 3, "%Q"},
+			{"%R %ERR", 3, "%ERR"},
+			{"%", 0, "%"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.layout, func(t *testing.T) {
+				res, err := marsTime.FormatE(tc.layout)
+				assert.Equal(t, res, "")
+				var formatErr *planets.FormatError
+				assert.Equal(t, errors.As(err, &formatErr), true)
+				assert.Equal(t, formatErr.Layout, tc.layout)
+				assert.Equal(t, formatErr.Offset, tc.offset)
+				assert.Equal(t, formatErr.Fragment, tc.fragment)
+				assert.Equal(t, marsTime.Format(tc.layout), "error: "+err.Error())
+			})
+		}
+	})
+}
+
+func TestMarsTimeAppendFormat(t *testing.T) {
+	marsTime := planets.MarsDate(221, 8, 26, 14, 35, 0, 0)
+	t.Run("Appends", func(t *testing.T) {
+		b, err := marsTime.AppendFormat([]byte("mars="), planets.MarsISO)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, string(b), "mars=221=08=26T14|35|00")
+	})
+	t.Run("ErrorKeepsBuffer", func(t *testing.T) {
+		b, err := marsTime.AppendFormat([]byte("mars="), "%R=%Q")
+		assert.NotEqual(t, err, nil)
+		assert.Equal(t, string(b), "mars=")
+	})
+	t.Run("DoesNotAllocate", func(t *testing.T) {
+		buf := make([]byte, 0, 128)
+		allocs := testing.AllocsPerRun(100, func() {
+			buf, _ = marsTime.AppendFormat(buf[:0], "%NS, %R %NM %oD @ %0V|%0L|%0F.%f0 %nS %nM %Vu")
+		})
+		assert.Equal(t, allocs, float64(0))
+	})
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name         string
+		layout       string
+		input        string
+		layoutOffset int
+		inputOffset  int
+		token        string
+		cause        error
+	}{
+		{"LiteralMismatch", "%R=%0M=%0S", "221-08=26", 2, 3, "", planets.ErrLiteralMismatch},
+		{"InputEnded", "%R=%0M=%0S", "221", 2, 3, "", planets.ErrLiteralMismatch},
+		{"TrailingInput", "%R=%0M=%0S", "221=08=26 !", 10, 9, "", planets.ErrTrailingInput},
+		{"InsufficientData", "%R=%0M", "221=08", 6, 6, "", planets.ErrInsufficientData},
+		{"MissingAMPM", "%R=%0M=%0S %V12", "221=08=26 1", 15, 11, "", planets.ErrMissingAMPM},
+		{"BadNumber", "%R=%0M=%0S", "221=MM=26", 3, 4, "%0M", nil},
+		{"BadMonthName", "%R %NM %S", "221 Month 26", 3, 4, "%NM", nil},
+		{"BadAMPM", "%R=%0M=%0S %Vl", "221=08=26 m", 11, 10, "%Vl", nil},
+		{"NoLeapSol", "%R=%0M=%0S", "222=24=28", 7, 7, "%0S", planets.ErrOutOfRange},
+		{"InconsistentWeekSol", "%R=%0M=%0S %NS", "221=08=26 Solis", 11, 10, "%NS", planets.ErrInconsistent},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := planets.MarsTime{}.Parse(tc.layout, tc.input)
+			var parseErr *planets.ParseError
+			assert.Equal(t, errors.As(err, &parseErr), true)
+			assert.Equal(t, parseErr.Layout, tc.layout)
+			assert.Equal(t, parseErr.Input, tc.input)
+			assert.Equal(t, parseErr.LayoutOffset, tc.layoutOffset)
+			assert.Equal(t, parseErr.InputOffset, tc.inputOffset)
+			assert.Equal(t, parseErr.Token, tc.token)
+			if tc.cause != nil {
+				assert.Equal(t, errors.Is(err, tc.cause), true)
+			}
+			var formatErr *planets.FormatError
+			assert.Equal(t, errors.As(err, &formatErr), false)
+		})
+	}
+	t.Run("BadLayout", func(t *testing.T) {
+		_, err := planets.MarsTime{}.Parse("%R %ERR", "221 x")
+		var parseErr *planets.ParseError
+		assert.Equal(t, errors.As(err, &parseErr), true)
+		assert.Equal(t, parseErr.LayoutOffset, 3)
+		assert.Equal(t, parseErr.Token, "%ERR")
+		var formatErr *planets.FormatError
+		assert.Equal(t, errors.As(err, &formatErr), true)
+		assert.Equal(t, formatErr.Fragment, "%ERR")
+	})
+	t.Run("ParseExample", func(t *testing.T) {
+		_, err := planets.MarsTime{}.ParseExample("203=04=05", "221=08-26")
+		var parseErr *planets.ParseError
+		assert.Equal(t, errors.As(err, &parseErr), true)
+		assert.Equal(t, parseErr.InputOffset, 6)
+		assert.Equal(t, errors.Is(err, planets.ErrLiteralMismatch), true)
+	})
+	t.Run("Message", func(t *testing.T) {
+		_, err := planets.MarsTime{}.Parse("%R=%0M=%0S", "221=MM=26")
+		assert.Equal(t, err.Error(), `parsing "221=MM=26" as "%R=%0M=%0S" at input[4] with token "%0M": expected numeric value, got "MM=26"`)
+	})
+}
+
+func TestParseValidation(t *testing.T) {
+	t.Run("OutOfRange", func(t *testing.T) {
+		tests := []struct {
+			layout      string
+			input       string
+			inputOffset int
+			token       string
+		}{
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "221=30=45T99|99|99", 4, "%0M"},
+			{"%R=%0M=%0S", "221=25=01", 4, "%0M"},
+			{"%R=%0M=%0S", "221=00=01", 4, "%0M"},
+			{"%R=%0M=%0S", "221=01=29", 7, "%0S"},
+			{"%R=%0M=%0S", "221=06=28", 7, "%0S"},
+			{"%R=%0M=%0S", "222=24=28", 7, "%0S"},
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "221=01=01T24|00|00", 10, "%0V"},
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "221=01=01T23|60|00", 13, "%0L"},
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "221=01=01T23|59|60", 16, "%0F"},
+			{"%R=%0M=%0S %Vu%V11", "221=01=01 A12", 11, "%V11"},
+			{"%R=%0M=%0S %Vu%V12", "221=01=01 A0", 11, "%V12"},
+			{"%R=%0M=%0S %Vu%V12", "221=01=01 A13", 11, "%V12"},
+			{"%R=%0M=%0S %Vu %V", "221=01=01 P 12", 12, "%V"},
+			{"%R=W%0W=%WS", "221=W97=1", 5, "%0W"},
+			{"%R=W%0W=%WS", "221=W01=8", 8, "%WS"},
+			{"%R=W%0W=%WS", "221=W24=7", 8, "%WS"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.input, func(t *testing.T) {
+				_, err := planets.MarsTime{}.Parse(tc.layout, tc.input)
+				assert.Equal(t, errors.Is(err, planets.ErrOutOfRange), true)
+				var parseErr *planets.ParseError
+				assert.Equal(t, errors.As(err, &parseErr), true)
+				assert.Equal(t, parseErr.InputOffset, tc.inputOffset)
+				assert.Equal(t, parseErr.Token, tc.token)
+			})
+		}
+	})
+	t.Run("TooLong", func(t *testing.T) {
+		// %f0 reads its nine digits and leaves the tenth unparsed
+		_, err := planets.MarsTime{}.Parse("%R=%0M=%0S.%f0", "221=01=01.1000000000")
+		assert.Equal(t, errors.Is(err, planets.ErrTrailingInput), true)
+	})
+	t.Run("Inconsistent", func(t *testing.T) {
+		tests := []struct {
+			layout      string
+			input       string
+			inputOffset int
+			token       string
+		}{
+			{"%NS, %R %NM %oS", "Solis, 221 Mina 26th", 0, "%NS"},
+			{"%WS %R=%0M=%0S", "1 221=08=26", 0, "%WS"},
+			{"%R=%0M=%0S W%W", "221=08=26 W1", 11, "%W"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.input, func(t *testing.T) {
+				_, err := planets.MarsTime{}.Parse(tc.layout, tc.input)
+				assert.Equal(t, errors.Is(err, planets.ErrInconsistent), true)
+				var parseErr *planets.ParseError
+				assert.Equal(t, errors.As(err, &parseErr), true)
+				assert.Equal(t, parseErr.InputOffset, tc.inputOffset)
+				assert.Equal(t, parseErr.Token, tc.token)
+			})
+		}
+	})
+	t.Run("Valid", func(t *testing.T) {
+		tests := []struct {
+			layout string
+			input  string
+		}{
+			{"%R=%0M=%0S%'T%0V|%0L|%0F", "221=24=28T23|59|59"},
+			{"%R=%0M=%0S", "221=06=27"},
+			{"%NS, %R %NM %oS", "Jovis, 221 Mina 26th"},
+			{"%R=%0M=%0S W%W %WS", "221=08=26 W32 5"},
+			{"%R=%0M=%0S %Vu%V12", "221=01=01 P12"},
+			{"%R=%0M=%0S %Vu%0V11", "221=01=01 P11"},
+			{"%R=W%0W=%WS", "221=W96=7"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.input, func(t *testing.T) {
+				marsTime, err := planets.MarsTime{}.Parse(tc.layout, tc.input)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, marsTime.Format(tc.layout), tc.input)
+			})
+		}
+	})
+}
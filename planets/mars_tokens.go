@@ -0,0 +1,369 @@
+package planets
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/HelloWorld-n/PlanetTime/format"
+)
+
+// tokenDef describes a layout token: how Format writes it, how Parse reads
+// it and which fragment of reference time ExampleToLayout replaces by it
+type tokenDef struct {
+	token       string
+	description string
+	// exampleKey is fragment of reference time 203=04=05T00|01|02.3
+	// ExampleToLayout replaces by token, empty if there is none
+	exampleKey string
+	// field is the value token stands for, 0 for "%%" and "%'"
+	field parsedField
+	// width and pad of numbers written with fixed width
+	width int
+	pad   byte
+	// variableWidth numbers are written with as many digits as needed
+	variableWidth bool
+	// lo and hi limit parsed numbers
+	lo, hi int
+	// value is the number written by numeric tokens, nil for other tokens
+	value func(f formatFields) int
+	// signed numbers are written with '+' when not negative
+	signed bool
+	// twelveHour numbers need AM/PM marker when parsed
+	twelveHour bool
+
+	format func(f formatFields, b []byte) []byte
+	parse  func(st *parseState, def *tokenDef, input string) (value int, consumed int, err error)
+}
+
+// TokenInfo describes a layout token for tooling
+type TokenInfo struct {
+	Token       string
+	Description string
+	// Example is the token formatted for reference time 203=04=05T00|01|02.3
+	Example string
+	// Width is width of numbers written with fixed width, 0 otherwise
+	Width int
+}
+
+// Tokens returns all layout tokens in the order README documents them
+func Tokens() []TokenInfo {
+	f := exampleTime.formatFields(usesDate | usesClock)
+	res := make([]TokenInfo, len(tokenTable))
+	for i, def := range tokenTable {
+		res[i] = TokenInfo{def.token, def.description, string(def.format(f, nil)), def.width}
+	}
+	return res
+}
+
+// number describes numeric token written by format.AppendPadded
+func number(def tokenDef, value func(f formatFields) int) *tokenDef {
+	def.variableWidth = def.width == 0
+	def.value = value
+	def.format = func(f formatFields, b []byte) []byte {
+		return format.AppendPadded(b, value(f), def.width, def.pad)
+	}
+	def.parse = parseNumberToken
+	return &def
+}
+
+// name describes token written as one of names of locale
+func name(def tokenDef, names func(l *Locale) []string, value func(f formatFields) int) *tokenDef {
+	def.format = func(f formatFields, b []byte) []byte {
+		return append(b, names(f.locale)[value(f)-1]...)
+	}
+	def.parse = func(st *parseState, def *tokenDef, input string) (int, int, error) {
+		n, nameLen, ok := parseName(input, names(st.locale()), st.opts.IgnoreCase)
+		if !ok {
+			return 0, 0, fmt.Errorf("no matching %s name found in %q", def.field.name(), input)
+		}
+		return n, nameLen, nil
+	}
+	return &def
+}
+
+// alias describes token that is another name of def
+func alias(token string, def *tokenDef) *tokenDef {
+	res := *def
+	res.token = token
+	res.description = "Same as `" + def.token + "`"
+	res.exampleKey = ""
+	return &res
+}
+
+func parseNumberToken(st *parseState, def *tokenDef, input string) (value int, consumed int, err error) {
+	value, consumed, err = st.parseNumeric(def.width, def.pad, input)
+	if err == nil {
+		err = checkRange(def.field.name(), value, def.lo, def.hi)
+	}
+	return
+}
+
+func parseRotation(st *parseState, def *tokenDef, input string) (value int, consumed int, err error) {
+	if def.width > 0 {
+		// width is only the minimum, as rotation has no upper bound;
+		// reserveDigits leaves digits of following tokens
+		value, consumed, err = format.ParseSignedNumeric(input, def.signed)
+		if err == nil && st.opts.Strict {
+			var buf [24]byte
+			expected := format.AppendPadded(buf[:0], value, def.width, def.pad)
+			if def.signed {
+				expected = format.AppendSigned(buf[:0], value, def.width, def.pad)
+			}
+			if string(expected) != input[:consumed] {
+				err = fmt.Errorf("expected %q, got %q", expected, input[:consumed])
+			}
+		}
+		return
+	}
+	if st.opts.Strict && len(input) > 0 && isSpace(input[0]) {
+		return 0, 0, fmt.Errorf("expected numeric value without leading spaces, got %q", input)
+	}
+	value, consumed, err = format.ParseSignedNumeric(input, def.signed)
+	if err == nil {
+		err = st.checkUnpadded(input[:consumed])
+	}
+	return
+}
+
+func parseOrdinal(st *parseState, def *tokenDef, input string) (value int, consumed int, err error) {
+	value, consumed, err = parseNumberToken(st, def, input)
+	if err == nil {
+		var suffixLen int
+		suffixLen, err = st.skipOrdinalSuffix(value, input[consumed:])
+		consumed += suffixLen
+	}
+	return
+}
+
+func parseTwelveHour(st *parseState, def *tokenDef, input string) (value int, consumed int, err error) {
+	value, consumed, err = parseNumberToken(st, def, input)
+	st.vinquaRequiresAMPM = true
+	return value % 12, consumed, err
+}
+
+func parseAMPM(st *parseState, def *tokenDef, input string) (int, int, error) {
+	st.vinquaFullfillsAMPM = true
+	l := st.locale()
+	for _, marker := range []struct {
+		text   string
+		vinqua int
+	}{{l.AM, 0}, {l.LowerAM, 0}, {l.PM, 12}, {l.LowerPM, 12}} {
+		if n, ok := prefixFold(input, marker.text, st.opts.IgnoreCase); ok {
+			return marker.vinqua, n, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("expected element of {%q, %q, %q, %q}, got %q", l.LowerAM, l.AM, l.LowerPM, l.PM, input)
+}
+
+func appendAMPM(b []byte, vinqua int, am string, pm string) []byte {
+	if vinqua >= 12 {
+		return append(b, pm...)
+	}
+	return append(b, am...)
+}
+
+var (
+	longMonthNames    = func(l *Locale) []string { return l.LongMonthNames }
+	shortMonthNames   = func(l *Locale) []string { return l.ShortMonthNames }
+	longWeekSolNames  = func(l *Locale) []string { return l.LongWeekSolNames }
+	shortWeekSolNames = func(l *Locale) []string { return l.ShortWeekSolNames }
+
+	rotationOf = func(f formatFields) int { return f.rotation }
+	monthOf    = func(f formatFields) int { return f.month }
+	weekOf     = func(f formatFields) int { return f.week }
+	solOf      = func(f formatFields) int { return f.sol }
+	weekSolOf  = func(f formatFields) int { return f.weekSol }
+	vinquaOf   = func(f formatFields) int { return f.vinqua }
+	vinqua11Of = func(f formatFields) int { return f.vinqua11 }
+	vinqua12Of = func(f formatFields) int { return f.vinqua12 }
+	layerOf    = func(f formatFields) int { return f.layer }
+	fragmentOf = func(f formatFields) int { return f.fragment }
+	remOf      = func(f formatFields) int { return f.rem }
+)
+
+var (
+	tokenSol    = number(tokenDef{token: "%S", description: "Sol (day)", exampleKey: "5", field: fieldSol, lo: 1, hi: solsInLongMonth}, solOf)
+	tokenOrdSol = &tokenDef{token: "%oS", description: "Ordinal Sol (e.g. 14th)", exampleKey: "5th", field: fieldSol, lo: 1, hi: solsInLongMonth, format: func(f formatFields, b []byte) []byte {
+		return append(format.AppendPadded(b, f.sol, 0, ' '), f.locale.ordinalSuffix(f.sol)...)
+	}, parse: parseOrdinal}
+	tokenZeroSol = number(tokenDef{token: "%0S", description: "Zero Padded Sol", exampleKey: "05", field: fieldSol, width: 2, pad: '0', lo: 1, hi: solsInLongMonth}, solOf)
+	tokenSpSol   = number(tokenDef{token: "%_S", description: "Space Padded Sol", exampleKey: "_5", field: fieldSol, width: 2, pad: ' ', lo: 1, hi: solsInLongMonth}, solOf)
+	tokenWeek    = number(tokenDef{token: "%W", description: "Week number", exampleKey: "!13", field: fieldWeek, lo: 1, hi: 96}, weekOf)
+	tokenWeekSol = number(tokenDef{token: "%WS", description: "Weekday number (1–7)", field: fieldWeekSol, lo: 1, hi: 7}, weekSolOf)
+	tokenLongWS  = name(tokenDef{token: "%NS", description: "Full Sol (weekday) name", exampleKey: "Jovis", field: fieldWeekSol}, longWeekSolNames, weekSolOf)
+	tokenShortWS = name(tokenDef{token: "%nS", description: "Abbreviated Sol name", exampleKey: "Jov", field: fieldWeekSol}, shortWeekSolNames, weekSolOf)
+)
+
+// tokenTable holds every layout token
+var tokenTable = []*tokenDef{
+	{token: "%R", description: "Rotation (year)", exampleKey: "203", field: fieldRotation, variableWidth: true, value: rotationOf,
+		format: func(f formatFields, b []byte) []byte { return format.AppendPadded(b, f.rotation, 0, ' ') },
+		parse:  parseRotation},
+	{token: "%+R", description: "Rotation with sign (e.g. `+221`, `-3`)", field: fieldRotation, variableWidth: true, value: rotationOf, signed: true,
+		format: func(f formatFields, b []byte) []byte { return format.AppendSigned(b, f.rotation, 0, ' ') },
+		parse:  parseRotation},
+
+	number(tokenDef{token: "%M", description: "Month (month)", exampleKey: "4", field: fieldMonth, lo: 1, hi: 24}, monthOf),
+	name(tokenDef{token: "%NM", description: "Full Month name", exampleKey: "Makara", field: fieldMonth}, longMonthNames, monthOf),
+	name(tokenDef{token: "%nM", description: "Abbreviated Month name", exampleKey: "Mak", field: fieldMonth}, shortMonthNames, monthOf),
+	number(tokenDef{token: "%0M", description: "Zero Padded Month", exampleKey: "04", field: fieldMonth, width: 2, pad: '0', lo: 1, hi: 24}, monthOf),
+	number(tokenDef{token: "%_M", description: "Space Padded Month", exampleKey: "_4", field: fieldMonth, width: 2, pad: ' ', lo: 1, hi: 24}, monthOf),
+
+	tokenWeek,
+	number(tokenDef{token: "%0W", description: "Zero Padded Week number", exampleKey: "13", field: fieldWeek, width: 2, pad: '0', lo: 1, hi: 96}, weekOf),
+	number(tokenDef{token: "%_W", description: "Space Padded Week number", field: fieldWeek, width: 2, pad: ' ', lo: 1, hi: 96}, weekOf),
+	alias("%w", tokenWeek),
+
+	tokenSol,
+	tokenOrdSol,
+	tokenSpSol,
+	tokenZeroSol,
+	alias("%D", tokenSol),
+	alias("%oD", tokenOrdSol),
+	alias("%_D", tokenSpSol),
+	alias("%0D", tokenZeroSol),
+
+	tokenWeekSol,
+	tokenLongWS,
+	tokenShortWS,
+	alias("%WD", tokenWeekSol),
+	alias("%ND", tokenLongWS),
+	alias("%nD", tokenShortWS),
+
+	number(tokenDef{token: "%V", description: "Vinqua (hour)", exampleKey: "0", field: fieldVinqua, lo: 0, hi: 23}, vinquaOf),
+	number(tokenDef{token: "%0V", description: "Zero Padded Vinqua", exampleKey: "00", field: fieldVinqua, width: 2, pad: '0', lo: 0, hi: 23}, vinquaOf),
+	number(tokenDef{token: "%_V", description: "Space Padded Vinqua", exampleKey: "_0", field: fieldVinqua, width: 2, pad: ' ', lo: 0, hi: 23}, vinquaOf),
+	{token: "%Vl", description: "`a` if Vinqua < 12 else `p`", field: fieldVinqua,
+		format: func(f formatFields, b []byte) []byte {
+			return appendAMPM(b, f.vinqua, f.locale.LowerAM, f.locale.LowerPM)
+		},
+		parse: parseAMPM},
+	{token: "%Vu", description: "`A` if Vinqua < 12 else `P`", field: fieldVinqua,
+		format: func(f formatFields, b []byte) []byte { return appendAMPM(b, f.vinqua, f.locale.AM, f.locale.PM) },
+		parse:  parseAMPM},
+	number(tokenDef{token: "%V11", description: "Vinqua % 12", field: fieldVinqua, lo: 0, hi: 11}, vinqua11Of),
+	number(tokenDef{token: "%0V11", description: "Zero Padded Vinqua % 12", field: fieldVinqua, width: 2, pad: '0', lo: 0, hi: 11}, vinqua11Of),
+	number(tokenDef{token: "%_V11", description: "Space Padded Vinqua % 12", field: fieldVinqua, width: 2, pad: ' ', lo: 0, hi: 11}, vinqua11Of),
+	twelveHour(number(tokenDef{token: "%V12", description: "Vinqua % 12 unless it is 0 in that case 12", exampleKey: "12", field: fieldVinqua, lo: 1, hi: 12}, vinqua12Of)),
+	twelveHour(number(tokenDef{token: "%0V12", description: "Zero Padded Vinqua % 12 unless it is 0 in that case 12", field: fieldVinqua, width: 2, pad: '0', lo: 1, hi: 12}, vinqua12Of)),
+	twelveHour(number(tokenDef{token: "%_V12", description: "Space Padded Vinqua % 12 unless it is 0 in that case 12", field: fieldVinqua, width: 2, pad: ' ', lo: 1, hi: 12}, vinqua12Of)),
+
+	number(tokenDef{token: "%L", description: "Layer (minute)", exampleKey: "1", field: fieldLayer, lo: 0, hi: 59}, layerOf),
+	number(tokenDef{token: "%0L", description: "Zero Padded Layer", exampleKey: "01", field: fieldLayer, width: 2, pad: '0', lo: 0, hi: 59}, layerOf),
+	number(tokenDef{token: "%_L", description: "Space Padded Layer", exampleKey: "_1", field: fieldLayer, width: 2, pad: ' ', lo: 0, hi: 59}, layerOf),
+
+	number(tokenDef{token: "%F", description: "Fragment (second)", exampleKey: "2", field: fieldFragment, lo: 0, hi: 59}, fragmentOf),
+	number(tokenDef{token: "%0F", description: "Zero Padded Fragment", exampleKey: "02", field: fieldFragment, width: 2, pad: '0', lo: 0, hi: 59}, fragmentOf),
+	number(tokenDef{token: "%_F", description: "Space Padded Fragment", exampleKey: "_2", field: fieldFragment, width: 2, pad: ' ', lo: 0, hi: 59}, fragmentOf),
+
+	{token: "%f", description: "NanoFragment, without trailing zeroes", exampleKey: "3", field: fieldNanofragment,
+		format: func(f formatFields, b []byte) []byte {
+			b = format.AppendPadded(b, f.rem, 9, '0')
+			// same as format.RemoveZeroesFromDecimalPortionOfNumber
+			for n := 0; n < 8 && b[len(b)-1] == '0'; n++ {
+				b = b[:len(b)-1]
+			}
+			return b
+		},
+		parse: func(st *parseState, def *tokenDef, input string) (int, int, error) {
+			value, consumed, err := format.ParseDecimal(input, 9)
+			if err == nil {
+				err = st.checkDigits(input[:consumed])
+			}
+			return value, consumed, err
+		}},
+	number(tokenDef{token: "%f0", description: "Zero Padded NanoFragment", exampleKey: "300000000", field: fieldNanofragment, width: 9, pad: '0', lo: 0, hi: int(time.Second) - 1}, remOf),
+
+	{token: "%%", description: "Literal `%` character",
+		format: func(f formatFields, b []byte) []byte { return append(b, '%') }},
+	{token: "%'", description: "Used to split tokens from text",
+		format: func(f formatFields, b []byte) []byte { return b }},
+}
+
+// twelveHour makes def parse vinqua that needs AM/PM marker
+func twelveHour(def *tokenDef) *tokenDef {
+	def.parse = parseTwelveHour
+	def.twelveHour = true
+	return def
+}
+
+// tokenDefs indexes tokenTable by token
+var tokenDefs = func() map[string]*tokenDef {
+	defs := make(map[string]*tokenDef, len(tokenTable))
+	for _, def := range tokenTable {
+		defs[def.token] = def
+	}
+	return defs
+}()
+
+// exampleCompounds are fragments of reference time ExampleToLayout
+// replaces by several tokens or by tokens and literal text
+var exampleCompounds = []struct {
+	example string
+	tokens  []string
+	suffix  string
+}{
+	{"A00", []string{"%Vu", "%0V11"}, ""},
+	{"A.", []string{"%Vu"}, "."},
+	{"a.", []string{"%Vl"}, "."},
+}
+
+// exampleReplacements are generated from tokenTable and exampleCompounds,
+// ordered from longest to shortest example
+var exampleReplacements = func() []exampleReplacement {
+	var res []exampleReplacement
+	for _, c := range exampleCompounds {
+		var descriptions []string
+		for _, token := range c.tokens {
+			descriptions = append(descriptions, tokenDefs[token].description)
+		}
+		res = append(res, exampleReplacement{c.example, strings.Join(c.tokens, "") + c.suffix, strings.Join(descriptions, ", then ")})
+	}
+	for _, def := range tokenTable {
+		if def.exampleKey != "" {
+			res = append(res, exampleReplacement{def.exampleKey, def.token, def.description})
+		}
+	}
+	slices.SortStableFunc(res, func(a, b exampleReplacement) int {
+		return len(b.example) - len(a.example)
+	})
+	return res
+}()
+
+// name returns name of value used in error messages
+func (p parsedField) name() string {
+	switch p {
+	case fieldRotation:
+		return "rotation"
+	case fieldMonth:
+		return "month"
+	case fieldSol:
+		return "sol"
+	case fieldVinqua:
+		return "vinqua"
+	case fieldLayer:
+		return "layer"
+	case fieldFragment:
+		return "fragment"
+	case fieldNanofragment:
+		return "nanofragment"
+	case fieldWeek:
+		return "week"
+	case fieldWeekSol:
+		return "weekSol"
+	}
+	return ""
+}
+
+// uses returns fields Format needs for value p
+func (p parsedField) uses() fieldUse {
+	switch p {
+	case 0:
+		return 0
+	case fieldVinqua, fieldLayer, fieldFragment, fieldNanofragment:
+		return usesClock
+	}
+	return usesDate
+}
@@ -0,0 +1,41 @@
+package planets_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func TestTokens(t *testing.T) {
+	reference := planets.MarsDate(203, 4, 5, 0, 1, 2, 300000000)
+	readme, err := os.ReadFile("../README.md")
+	assert.Equal(t, err, nil)
+
+	seen := map[string]bool{}
+	for _, token := range planets.Tokens() {
+		t.Run(token.Token, func(t *testing.T) {
+			assert.Equal(t, seen[token.Token], false)
+			seen[token.Token] = true
+
+			formatted, err := reference.FormatE(token.Token)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, formatted, token.Example)
+			if token.Width > 0 {
+				assert.Equal(t, len(token.Example), token.Width)
+			}
+			assert.Equal(t, strings.Contains(string(readme), "| `"+token.Token+"` "), true)
+		})
+	}
+	t.Run("WeekAlias", func(t *testing.T) {
+		expected, err := planets.MarsTime{}.Parse("%R=%W=%WS", "221=30=5")
+		assert.Equal(t, err, nil)
+		marsTime, err := planets.MarsTime{}.Parse("%R=%w=%WS", "221=30=5")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, marsTime, expected)
+		assert.Equal(t, marsTime.Format("%w"), marsTime.Format("%W"))
+	})
+}
@@ -0,0 +1,119 @@
+package planets_test
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/HelloWorld-n/PlanetTime/planets"
+)
+
+func mustLocale(t *testing.T, name string) *planets.Locale {
+	locale, ok := planets.LookupLocale(name)
+	assert.Equal(t, ok, true)
+	return locale
+}
+
+func TestUnicodeLayout(t *testing.T) {
+	marsTime := planets.MarsDate(221, 8, 26, 14, 35, 0, 0)
+	layout := "♂ %R→%0M→%0S ♂"
+	result := marsTime.Format(layout)
+	assert.Equal(t, result, "♂ 221→08→26 ♂")
+
+	parsed, err := marsTime.Parse(layout, result)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, planets.MarsDate(221, 8, 26, 0, 0, 0, 0))
+
+	_, err = marsTime.Parse(layout, "♂ 221→08→26 ♀")
+	var parseErr *planets.ParseError
+	assert.Equal(t, errors.As(err, &parseErr), true)
+	assert.Equal(t, parseErr.InputOffset, 18)
+	assert.Equal(t, parseErr.InputColumn, 12)
+	assert.Equal(t, parseErr.LayoutOffset, 19)
+	assert.Equal(t, parseErr.LayoutColumn, 13)
+	assert.Equal(t, errors.Is(err, planets.ErrLiteralMismatch), true)
+	assert.Equal(t, err.Error(), `parsing "♂ 221→08→26 ♀" as "♂ %R→%0M→%0S ♂" at input[18] (column 12): input does not match layout literal: expected '♂', got '♀'`)
+
+	parsed, err = marsTime.ParseWithOptions("%R　%0M", "221 08", planets.ParseOptions{FlexibleSpace: true, Reference: &marsTime})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed.Format("%R=%0M"), "221=08")
+}
+
+func TestUnicodeFormatError(t *testing.T) {
+	_, err := planets.MarsTime{}.FormatE("♂♂ %Zé!")
+	var formatErr *planets.FormatError
+	assert.Equal(t, errors.As(err, &formatErr), true)
+	assert.Equal(t, formatErr.Offset, 7)
+	assert.Equal(t, formatErr.Column, 3)
+	assert.Equal(t, formatErr.Fragment, "%Zé")
+
+	_, err = planets.MarsTime{}.Parse("♂ %Zé", "♂ x")
+	var parseErr *planets.ParseError
+	assert.Equal(t, errors.As(err, &parseErr), true)
+	assert.Equal(t, parseErr.LayoutOffset, 4)
+	assert.Equal(t, parseErr.LayoutColumn, 2)
+}
+
+func TestUnicodePadding(t *testing.T) {
+	marsTime := planets.MarsDate(221, 24, 5, 0, 0, 0, 0)
+	tests := []struct {
+		locale   string
+		layout   string
+		expected string
+	}{
+		{"ja", "%0S %12NM|%_6NS|", "05   ヴリシチカ|木曜日|"},
+		{"hi", "%0S %8NM|%_6NS|", "05    वृश्चिक| गुरुवार|"},
+		{"de", "%0S %^10NM|%,5nS|", "05   VRISHIKA|   do|"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.locale, func(t *testing.T) {
+			locale := mustLocale(t, test.locale)
+			result, err := marsTime.FormatLocale(test.layout, locale)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, result, test.expected)
+
+			_, err = marsTime.ParseWithOptions(test.layout, result, planets.ParseOptions{Strict: true, Locale: locale, Reference: &marsTime})
+			assert.Equal(t, err, nil)
+		})
+	}
+
+	_, err := marsTime.ParseWithOptions("%12NM|", " ヴリシチカ|", planets.ParseOptions{Strict: true, Locale: mustLocale(t, "ja")})
+	assert.NotEqual(t, err, nil)
+}
+
+func TestUnicodeNames(t *testing.T) {
+	de := mustLocale(t, "de")
+	parsed, err := planets.MarsTime{}.ParseWithOptions("%oS %NM %R", "5. SCHÜTZE 221", planets.ParseOptions{IgnoreCase: true, Locale: de})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, planets.MarsDate(221, 1, 5, 0, 0, 0, 0))
+
+	_, err = planets.MarsTime{}.ParseWithOptions("%oS %NM %R", "5. SCHÜTZE 221", planets.ParseOptions{Locale: de})
+	assert.NotEqual(t, err, nil)
+
+	hi := mustLocale(t, "hi")
+	n, nameLen, err := hi.ParseMonthName("मकर 5", true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, n, 4)
+	assert.Equal(t, nameLen, len("मकर"))
+
+	// "10月" must not be read as "1月"
+	ja := mustLocale(t, "ja")
+	n, _, err = ja.ParseMonthName("10月", false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, n, 10)
+}
+
+func TestUnicodeExampleError(t *testing.T) {
+	_, _, err := planets.MarsTime{}.ExampleToLayoutE("☉ 77")
+	var exampleErr *planets.ExampleError
+	assert.Equal(t, errors.As(err, &exampleErr), true)
+	assert.Equal(t, exampleErr.Offset, 4)
+	assert.Equal(t, exampleErr.Column, 2)
+
+	_, _, err = planets.MarsTime{}.ExampleToLayoutE("Makaraé")
+	assert.Equal(t, errors.Is(err, planets.ErrAmbiguousExample), true)
+	assert.Equal(t, errors.As(err, &exampleErr), true)
+	assert.Equal(t, exampleErr.Fragment, "Makaraé")
+}